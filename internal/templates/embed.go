@@ -1,6 +1,7 @@
 package templates
 
 import (
+	"bytes"
 	"embed"
 	"fmt"
 	"io/fs"
@@ -26,32 +27,26 @@ func New(outputPath string) *Generator {
 
 // Generate processa um template embarcado e gera o arquivo
 func (g *Generator) Generate(templateName string, outputFile string, data interface{}) error {
-	// 1. Ler template do embed
-	templateContent, err := TemplatesFS.ReadFile(templateName)
-	if err != nil {
-		return fmt.Errorf("erro ao ler template embarcado %s: %w", templateName, err)
-	}
-
-	// 2. Parsear template
-	tmpl, err := template.New(templateName).Funcs(getFuncMap()).Parse(string(templateContent))
+	// 1. Ler e parsear template do embed
+	tmpl, err := parse(templateName)
 	if err != nil {
-		return fmt.Errorf("erro ao parsear template: %w", err)
+		return err
 	}
 
-	// 3. Criar diretório de saída se não existir
+	// 2. Criar diretório de saída se não existir
 	outputPath := filepath.Join(g.OutputPath, outputFile)
 	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
 		return fmt.Errorf("erro ao criar diretório: %w", err)
 	}
 
-	// 4. Criar arquivo de saída
+	// 3. Criar arquivo de saída
 	file, err := os.Create(outputPath)
 	if err != nil {
 		return fmt.Errorf("erro ao criar arquivo: %w", err)
 	}
 	defer file.Close()
 
-	// 5. Executar template e escrever no arquivo
+	// 4. Executar template e escrever no arquivo
 	if err := tmpl.Execute(file, data); err != nil {
 		return fmt.Errorf("erro ao executar template: %w", err)
 	}
@@ -59,6 +54,33 @@ func (g *Generator) Generate(templateName string, outputFile string, data interf
 	return nil
 }
 
+// Render processa um template embarcado e retorna o conteúdo sem gravar arquivo
+func Render(templateName string, data interface{}) ([]byte, error) {
+	tmpl, err := parse(templateName)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("erro ao executar template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func parse(templateName string) (*template.Template, error) {
+	templateContent, err := TemplatesFS.ReadFile(templateName)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler template embarcado %s: %w", templateName, err)
+	}
+
+	tmpl, err := template.New(templateName).Funcs(getFuncMap()).Parse(string(templateContent))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao parsear template: %w", err)
+	}
+	return tmpl, nil
+}
+
 // getFuncMap retorna funções customizadas para usar nos templates
 func getFuncMap() template.FuncMap {
 	return template.FuncMap{
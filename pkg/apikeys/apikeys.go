@@ -0,0 +1,130 @@
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TableName é a tabela gerada por 'gaver add apikeys'
+const TableName = "api_keys"
+
+// keyPrefix identifica as keys geradas pelo gaver
+const keyPrefix = "gvr_"
+
+// prefixLength é o tamanho do prefixo guardado em texto para identificar a key
+const prefixLength = 12
+
+// Key é uma linha da tabela api_keys (mesmas colunas do model
+// config/apikeys.APIKey do projeto)
+type Key struct {
+	ID         string `gorm:"primaryKey"`
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// TableName usa a tabela api_keys
+func (Key) TableName() string {
+	return TableName
+}
+
+// Status descreve a situação da key (ativa, expirada ou revogada)
+func (k *Key) Status() string {
+	switch {
+	case k.RevokedAt != nil:
+		return "revogada"
+	case k.ExpiresAt != nil && !time.Now().Before(*k.ExpiresAt):
+		return "expirada"
+	default:
+		return "ativa"
+	}
+}
+
+// Hash retorna o hash SHA-256 (hex) da key, igual ao apikeys.Hash do projeto
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// EnsureTable verifica se a migration da tabela api_keys já foi aplicada
+func EnsureTable(db *gorm.DB) error {
+	if !db.Migrator().HasTable(TableName) {
+		return fmt.Errorf("tabela %s não existe: rode 'gaver add apikeys' e 'gaver migrate up'", TableName)
+	}
+	return nil
+}
+
+// Create gera uma key aleatória e grava apenas o hash. A key em texto é
+// retornada uma única vez.
+func Create(db *gorm.DB, name string, scopes []string, expiresAt *time.Time) (string, *Key, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, fmt.Errorf("erro ao gerar key: %w", err)
+	}
+	plain := keyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	key := &Key{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Prefix:    plain[:prefixLength],
+		KeyHash:   Hash(plain),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}
+	if err := db.Create(key).Error; err != nil {
+		return "", nil, fmt.Errorf("erro ao gravar key: %w", err)
+	}
+
+	return plain, key, nil
+}
+
+// List retorna todas as keys, das mais recentes para as mais antigas
+func List(db *gorm.DB) ([]Key, error) {
+	var keys []Key
+	if err := db.Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("erro ao listar keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke revoga a key pelo ID ou pelo prefixo exibido em 'gaver apikey list'
+func Revoke(db *gorm.DB, ref string) (*Key, error) {
+	var keys []Key
+	if err := db.Where("id = ? OR prefix = ?", ref, ref).Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("erro ao buscar key: %w", err)
+	}
+
+	switch len(keys) {
+	case 0:
+		return nil, fmt.Errorf("key '%s' não encontrada", ref)
+	case 1:
+	default:
+		return nil, fmt.Errorf("mais de uma key com prefixo '%s': use o ID", ref)
+	}
+
+	key := &keys[0]
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	if err := db.Model(key).Update("revoked_at", now).Error; err != nil {
+		return nil, fmt.Errorf("erro ao revogar key: %w", err)
+	}
+	key.RevokedAt = &now
+
+	return key, nil
+}
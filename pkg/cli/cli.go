@@ -38,4 +38,12 @@ func (cli *CLI) registerCommands() {
 	cli.RootCmd.AddCommand(commands.NewMigrateCommand())
 	cli.RootCmd.AddCommand(commands.NewServeCommand())
 	cli.RootCmd.AddCommand(commands.NewBuildCommand())
+	cli.RootCmd.AddCommand(commands.NewOpenAPICommand())
+	cli.RootCmd.AddCommand(commands.NewFrontendCommand())
+	cli.RootCmd.AddCommand(commands.NewAddCommand())
+	cli.RootCmd.AddCommand(commands.NewAPIKeyCommand())
+	cli.RootCmd.AddCommand(commands.NewJobsCommand())
+	cli.RootCmd.AddCommand(commands.NewRoutineCommand())
+	cli.RootCmd.AddCommand(commands.NewMakeCommand())
+	cli.RootCmd.AddCommand(commands.NewDBCommand())
 }
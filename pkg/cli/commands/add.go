@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Dalistor/gaver/pkg/modules"
+
+	"github.com/spf13/cobra"
+)
+
+func NewAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Adiciona recursos prontos ao projeto",
+		Long:  "Gera módulos completos (models, rotas e configuração) para recursos comuns",
+	}
+
+	cmd.AddCommand(newAddAuthCommand())
+	cmd.AddCommand(newAddAPIKeysCommand())
+	cmd.AddCommand(newAddTestUtilCommand())
+
+	return cmd
+}
+
+func newAddAuthCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "auth",
+		Short: "Gera o módulo de autenticação com JWT e refresh tokens",
+		Long: `Gera o módulo auth com:
+
+  - Model User (senha com hash bcrypt) e RefreshToken
+  - Rotas /auth/register, /auth/login, /auth/refresh, /auth/logout e /auth/me
+  - Access tokens JWT (HS256) assinados com JWT_SECRET do .env
+  - Refresh tokens com rotação, gravados no banco (apenas o hash)
+
+O middleware Auth de config/middlewares passa a validar o token e guardar o
+usuário no contexto (auth.CurrentUserID, auth.CurrentRole).`,
+		Example: `  gaver add auth`,
+		Args:    cobra.NoArgs,
+		RunE:    runAddAuth,
+	}
+}
+
+func runAddAuth(cmd *cobra.Command, args []string) error {
+	fmt.Println("Gerando módulo de autenticação...")
+
+	if err := modules.CreateAuthModule(); err != nil {
+		return fmt.Errorf("erro ao gerar módulo auth: %w", err)
+	}
+
+	fmt.Printf("✓ Módulo auth gerado com sucesso!\n\n")
+	fmt.Println("Arquivos criados:")
+	for _, file := range modules.AuthFiles() {
+		fmt.Printf("  - %s\n", file)
+	}
+	fmt.Println("\nArquivos atualizados:")
+	fmt.Println("  - config/middlewares/middlewares.go (Auth valida o JWT)")
+	fmt.Println("  - config/modules/modules.go")
+	fmt.Println("  - .env e .env.example (JWT_SECRET, JWT_ACCESS_TTL, JWT_REFRESH_TTL)")
+
+	syncFrontendAfterChange()
+
+	fmt.Println("\n📝 Próximos passos:")
+	fmt.Println("  go mod tidy")
+	fmt.Println("  gaver makemigrations -n auth")
+	fmt.Println("  gaver migrate up")
+	fmt.Println("\nProteja rotas com middlewares.Auth():")
+	fmt.Println("  router.GET(\"/products\", middlewares.Auth(), productHandler.List)")
+
+	return nil
+}
+
+func newAddAPIKeysCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "apikeys",
+		Short: "Gera a tabela de API keys e o middleware APIKeyAuth",
+		Long: `Prepara o projeto para autenticação por API key:
+
+  - Pacote config/apikeys com o model APIKey (hash, escopos, validade e último uso)
+  - Migration da tabela api_keys
+  - middlewares.APIKeyAuth(escopos...) validando a key do header X-API-Key
+
+As keys são gerenciadas com 'gaver apikey create|list|revoke'.`,
+		Example: `  gaver add apikeys`,
+		Args:    cobra.NoArgs,
+		RunE:    runAddAPIKeys,
+	}
+}
+
+func runAddAPIKeys(cmd *cobra.Command, args []string) error {
+	fmt.Println("Gerando suporte a API keys...")
+
+	migrationFile, err := modules.AddAPIKeys()
+	if err != nil {
+		return fmt.Errorf("erro ao gerar API keys: %w", err)
+	}
+
+	fmt.Printf("✓ API keys configuradas com sucesso!\n\n")
+	fmt.Println("Arquivos:")
+	fmt.Println("  - config/apikeys/api_key.go")
+	fmt.Println("  - config/apikeys/apikeys.go")
+	fmt.Printf("  - migrations/%s\n", migrationFile)
+	fmt.Println("  - config/middlewares/middlewares.go (APIKeyAuth valida a key)")
+
+	fmt.Println("\n📝 Próximos passos:")
+	fmt.Println("  gaver migrate up")
+	fmt.Println("  gaver apikey create erp --scopes orders:read")
+	fmt.Println("\nProteja grupos de rotas com middlewares.APIKeyAuth():")
+	fmt.Println("  integrations := router.Group(\"/integrations\", middlewares.APIKeyAuth(\"orders:read\"))")
+
+	return nil
+}
+
+func newAddTestUtilCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "testutil",
+		Short: "Gera o harness de testes de integração (config/testutil)",
+		Long: `Prepara projetos criados antes do harness de testes:
+
+  - config/app com o router da aplicação (middlewares e rotas dos módulos)
+  - config/testutil: aplicação completa sobre um SQLite temporário, migrations
+    de migrations/, cliente HTTP com AsUser e asserções JSON, e uma transação
+    desfeita ao final de cada teste
+  - tests/main_test.go com o TestMain e um teste de exemplo
+
+Projetos novos já são criados com o harness.`,
+		Example: `  gaver add testutil`,
+		Args:    cobra.NoArgs,
+		RunE:    runAddTestUtil,
+	}
+}
+
+func runAddTestUtil(cmd *cobra.Command, args []string) error {
+	fmt.Println("Gerando harness de testes...")
+
+	created, err := modules.AddTestUtil()
+	if err != nil {
+		return fmt.Errorf("erro ao gerar harness de testes: %w", err)
+	}
+
+	fmt.Printf("✓ Harness de testes configurado com sucesso!\n\n")
+	if len(created) > 0 {
+		fmt.Println("Arquivos criados:")
+		for _, file := range created {
+			fmt.Printf("  - %s\n", file)
+		}
+	}
+	fmt.Println("\nArquivos atualizados:")
+	fmt.Println("  - config/database/migrations/migrations.go (executa apenas o UP)")
+
+	fmt.Println("\n📝 Próximos passos:")
+	fmt.Println("  go mod tidy")
+	fmt.Println("  go test ./tests/...")
+	fmt.Println("\nPara que os testes usem o mesmo router do servidor, monte-o em cmd/server/main.go com:")
+	fmt.Println("  router := app.NewRouter(moduleRegistry, routineManager)")
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Dalistor/gaver/pkg/apikeys"
+	"github.com/Dalistor/gaver/pkg/migrations"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func NewAPIKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "Gerencia as API keys do projeto",
+		Long: `Cria, lista e revoga as API keys validadas por middlewares.APIKeyAuth.
+
+Usa o banco configurado no .env. A tabela api_keys é criada com
+'gaver add apikeys' e 'gaver migrate up'.`,
+	}
+
+	cmd.AddCommand(newAPIKeyCreateCommand())
+	cmd.AddCommand(newAPIKeyListCommand())
+	cmd.AddCommand(newAPIKeyRevokeCommand())
+
+	return cmd
+}
+
+func newAPIKeyCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [nome]",
+		Short: "Cria uma API key",
+		Long:  "Gera uma API key aleatória. A key é exibida apenas uma vez; o banco guarda somente o hash.",
+		Example: `  gaver apikey create erp --scopes orders:read,orders:write
+  gaver apikey create parceiro --scopes "*" --expires 90d`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIKeyCreate,
+	}
+
+	cmd.Flags().StringSlice("scopes", nil, "Escopos da key, separados por vírgula (\"*\" libera todos)")
+	cmd.Flags().String("expires", "", "Validade da key (ex: 720h, 90d). Vazio = não expira")
+
+	return cmd
+}
+
+func runAPIKeyCreate(cmd *cobra.Command, args []string) error {
+	scopes, _ := cmd.Flags().GetStringSlice("scopes")
+	expires, _ := cmd.Flags().GetString("expires")
+
+	var expiresAt *time.Time
+	if expires != "" {
+		duration, err := parseDuration(expires)
+		if err != nil {
+			return err
+		}
+		at := time.Now().Add(duration)
+		expiresAt = &at
+	}
+
+	db, err := connectAPIKeysDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	plain, key, err := apikeys.Create(db, args[0], scopes, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ API key '%s' criada (ID: %s)\n\n", key.Name, key.ID)
+	fmt.Printf("  %s\n\n", plain)
+	fmt.Println("⚠️  Guarde a key agora: ela não será exibida novamente.")
+	fmt.Println("    Envie no header X-API-Key.")
+
+	return nil
+}
+
+func newAPIKeyListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lista as API keys",
+		Args:  cobra.NoArgs,
+		RunE:  runAPIKeyList,
+	}
+}
+
+func runAPIKeyList(cmd *cobra.Command, args []string) error {
+	db, err := connectAPIKeysDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	keys, err := apikeys.List(db)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		fmt.Println("Nenhuma API key cadastrada")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNOME\tPREFIXO\tESCOPOS\tEXPIRA EM\tÚLTIMO USO\tSTATUS")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID, key.Name, key.Prefix, key.Scopes,
+			formatKeyTime(key.ExpiresAt), formatKeyTime(key.LastUsedAt), key.Status())
+	}
+	return w.Flush()
+}
+
+func newAPIKeyRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "revoke [id|prefixo]",
+		Short:   "Revoga uma API key",
+		Example: `  gaver apikey revoke gvr_AbCdEfGh`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runAPIKeyRevoke,
+	}
+}
+
+func runAPIKeyRevoke(cmd *cobra.Command, args []string) error {
+	db, err := connectAPIKeysDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	key, err := apikeys.Revoke(db, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ API key '%s' (%s) revogada\n", key.Name, key.Prefix)
+	return nil
+}
+
+// connectAPIKeysDB conecta ao banco do projeto e verifica a tabela api_keys
+func connectAPIKeysDB() (*gorm.DB, error) {
+	db, err := migrations.ConnectDB()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar ao banco: %w", err)
+	}
+
+	if err := apikeys.EnsureTable(db); err != nil {
+		migrations.CloseDB()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// parseDuration aceita durações do Go (720h) e dias (90d)
+func parseDuration(value string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(value, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("duração inválida: %s", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("duração inválida: %s (use por exemplo 720h ou 90d)", value)
+	}
+	return duration, nil
+}
+
+func formatKeyTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dalistor/gaver/pkg/database"
+	"github.com/Dalistor/gaver/pkg/migrations"
+	"github.com/Dalistor/gaver/pkg/modules"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func NewDBCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Operações no banco de dados do projeto",
+		Long: `Popula, exporta, importa e consulta o banco configurado no .env.
+
+Os comandos usam a mesma conexão das migrations e não dependem de
+ferramentas do banco (sqlite3, pg_dump, mysqldump).`,
+	}
+
+	cmd.AddCommand(newDBSeedCommand())
+	cmd.AddCommand(newDBDumpCommand())
+	cmd.AddCommand(newDBRestoreCommand())
+	cmd.AddCommand(newDBShellCommand())
+	cmd.AddCommand(newDBWipeCommand())
+
+	return cmd
+}
+
+func newDBSeedCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Executa os seeders",
+		Long: `Executa os seeders de seeders/ na ordem de RegisterDefaultSeeders, cada
+um em uma transação, com o banco do .env (via go run ./cmd/seed).
+
+As tabelas precisam existir: aplique as migrations antes com 'gaver migrate up'.`,
+		Example: `  gaver db seed
+  gaver db seed --class products
+  gaver db seed --class users,products --seed 42
+  gaver db seed --list`,
+		Args: cobra.NoArgs,
+		RunE: runDBSeed,
+	}
+
+	cmd.Flags().StringSlice("class", nil, "Seeders a executar (padrão: todos)")
+	cmd.Flags().Bool("list", false, "Listar os seeders na ordem de execução")
+	cmd.Flags().Uint64("seed", 0, "Semente dos valores aleatórios das factories, para repetir os mesmos dados")
+
+	return cmd
+}
+
+func runDBSeed(cmd *cobra.Command, args []string) error {
+	classes, _ := cmd.Flags().GetStringSlice("class")
+	list, _ := cmd.Flags().GetBool("list")
+	seed, _ := cmd.Flags().GetUint64("seed")
+
+	// Projetos criados antes dos seeders
+	created, err := modules.EnsureSeederSupport()
+	if err != nil {
+		return err
+	}
+	for _, file := range created {
+		fmt.Printf("✓ Criado %s\n", file)
+	}
+
+	runArgs := []string{"run", "./cmd/seed"}
+	if len(classes) > 0 {
+		runArgs = append(runArgs, "--class", strings.Join(classes, ","))
+	}
+	if list {
+		runArgs = append(runArgs, "--list")
+	}
+	if seed != 0 {
+		runArgs = append(runArgs, "--seed", strconv.FormatUint(seed, 10))
+	}
+
+	run := exec.Command("go", runArgs...)
+	run.Stdout = os.Stdout
+	run.Stderr = os.Stderr
+	run.Stdin = os.Stdin
+
+	if err := run.Run(); err != nil {
+		return fmt.Errorf("erro ao executar seeders: %w", err)
+	}
+	return nil
+}
+
+func newDBDumpCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Exporta os dados do banco",
+		Long: `Exporta os dados de todas as tabelas (exceto migrations) na ordem das
+chaves estrangeiras. Apenas os dados: o schema vem das migrations.
+
+Formatos:
+  json  portável entre bancos (ex: do SQLite para o PostgreSQL)
+  sql   INSERTs no dialeto do banco de origem
+
+O formato é deduzido da extensão de --output (json por padrão).`,
+		Example: `  gaver db dump
+  gaver db dump -o backup.sql
+  gaver db dump --tables products,categories -o catalogo.json
+  gaver db dump --exclude jobs -o - | gzip > backup.json.gz`,
+		Args: cobra.NoArgs,
+		RunE: runDBDump,
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Arquivo do dump, - para a saída padrão (padrão: dump_<data>.json)")
+	cmd.Flags().String("format", "", "Formato do dump: json ou sql")
+	cmd.Flags().StringSlice("tables", nil, "Tabelas exportadas (padrão: todas)")
+	cmd.Flags().StringSlice("exclude", nil, "Tabelas ignoradas")
+
+	return cmd
+}
+
+func runDBDump(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	formatName, _ := cmd.Flags().GetString("format")
+	tables, _ := cmd.Flags().GetStringSlice("tables")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+
+	format := database.FormatFromPath(output)
+	if formatName != "" {
+		var err error
+		if format, err = database.ParseFormat(formatName); err != nil {
+			return err
+		}
+	}
+	if output == "" {
+		output = fmt.Sprintf("dump_%s.%s", time.Now().Format("20060102_150405"), format)
+	}
+
+	db, err := connectDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	// Com a saída padrão, as mensagens vão para o stderr
+	var w io.Writer = os.Stdout
+	messages := os.Stdout
+	if output == "-" {
+		messages = os.Stderr
+	} else {
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("erro ao criar %s: %w", output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	counts, err := database.Dump(db, w, database.DumpOptions{Format: format, Tables: tables, Exclude: exclude})
+	if err != nil {
+		if output != "-" {
+			os.Remove(output)
+		}
+		return err
+	}
+
+	total := 0
+	for _, count := range counts {
+		fmt.Fprintf(messages, "  %s: %d registro(s)\n", count.Table, count.Rows)
+		total += count.Rows
+	}
+	if output != "-" {
+		fmt.Fprintf(messages, "✓ Dump de %d tabela(s) e %d registro(s) salvo em %s\n", len(counts), total, output)
+	}
+	return nil
+}
+
+func newDBRestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [arquivo]",
+		Short: "Importa um dump gerado por gaver db dump",
+		Long: `Insere os dados do dump em uma única transação: em caso de erro, nada é
+alterado. As tabelas já devem existir, aplique as migrations antes com
+'gaver migrate up'.
+
+Com --clean, os registros das tabelas do dump são apagados antes.`,
+		Example: `  gaver db restore dump_20250101_120000.json
+  gaver db restore backup.sql --clean
+  gaver db wipe -y && gaver migrate up && gaver db restore backup.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runDBRestore,
+	}
+
+	cmd.Flags().String("format", "", "Formato do dump: json ou sql (padrão: pela extensão)")
+	cmd.Flags().Bool("clean", false, "Apagar os registros das tabelas do dump antes de importar")
+	cmd.Flags().BoolP("yes", "y", false, "Não pede confirmação")
+
+	return cmd
+}
+
+func runDBRestore(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	formatName, _ := cmd.Flags().GetString("format")
+	clean, _ := cmd.Flags().GetBool("clean")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	format := database.FormatFromPath(path)
+	if formatName != "" {
+		var err error
+		if format, err = database.ParseFormat(formatName); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if clean && !yes && !confirm("Apagar os registros atuais das tabelas do dump?") {
+		fmt.Println("Operação cancelada")
+		return nil
+	}
+
+	db, err := connectDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	counts, err := database.Restore(db, file, database.RestoreOptions{Format: format, Clean: clean})
+	if err != nil {
+		return fmt.Errorf("erro ao restaurar %s: %w", path, err)
+	}
+
+	total := 0
+	for _, count := range counts {
+		fmt.Printf("  %s: %d registro(s)\n", count.Table, count.Rows)
+		total += count.Rows
+	}
+	fmt.Printf("✓ %d registro(s) restaurado(s) de %s\n", total, path)
+	return nil
+}
+
+func newDBShellCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: "Abre um prompt SQL no banco do projeto",
+		Long: `Abre um prompt SQL interativo com a conexão do .env. Os comandos terminam
+com ";" e podem ocupar várias linhas. Digite \? para ver os meta-comandos
+e \q para sair.
+
+Também lê comandos da entrada padrão ou de --command.`,
+		Example: `  gaver db shell
+  gaver db shell -c "SELECT COUNT(*) FROM products"
+  gaver db shell < consultas.sql`,
+		Args: cobra.NoArgs,
+		RunE: runDBShell,
+	}
+
+	cmd.Flags().StringP("command", "c", "", "Executar o comando SQL e sair")
+
+	return cmd
+}
+
+func runDBShell(cmd *cobra.Command, args []string) error {
+	command, _ := cmd.Flags().GetString("command")
+
+	db, err := connectDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	if command != "" {
+		return database.Execute(db, command, os.Stdout)
+	}
+
+	interactive := isTerminal(os.Stdin)
+	if interactive {
+		fmt.Printf("Conectado ao banco %s. Digite \\? para ajuda e \\q para sair.\n", db.Dialector.Name())
+	}
+
+	shell := &database.Shell{DB: db, In: os.Stdin, Out: os.Stdout, Prompt: interactive}
+	return shell.Run()
+}
+
+func newDBWipeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wipe",
+		Short: "Remove todas as tabelas do banco",
+		Long: `Remove todas as tabelas do banco do .env, inclusive a de migrations.
+Recrie o schema depois com 'gaver migrate up'.
+
+Pede confirmação, a menos que --yes seja informado.`,
+		Example: `  gaver db wipe
+  gaver db wipe -y && gaver migrate up && gaver db seed`,
+		Args: cobra.NoArgs,
+		RunE: runDBWipe,
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "Não pede confirmação")
+
+	return cmd
+}
+
+func runDBWipe(cmd *cobra.Command, args []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	db, err := connectDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	tables, err := database.Tables(db)
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		fmt.Println("O banco não tem tabelas")
+		return nil
+	}
+
+	fmt.Printf("Tabelas que serão removidas (%s):\n", db.Dialector.Name())
+	for _, table := range tables {
+		fmt.Printf("  - %s\n", table)
+	}
+	if !yes && !confirm("Remover todas as tabelas e seus dados?") {
+		fmt.Println("Operação cancelada")
+		return nil
+	}
+
+	removed, err := database.Wipe(db)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %d tabela(s) removida(s)\n", len(removed))
+	fmt.Println("\nRecrie o schema com: gaver migrate up")
+	return nil
+}
+
+func connectDB() (*gorm.DB, error) {
+	db, err := migrations.ConnectDB()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar ao banco: %w", err)
+	}
+	return db, nil
+}
+
+// isTerminal verifica se o arquivo é um terminal (e não um pipe ou arquivo)
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
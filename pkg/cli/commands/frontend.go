@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Dalistor/gaver/pkg/frontend"
+	"github.com/Dalistor/gaver/pkg/openapi"
+
+	"github.com/spf13/cobra"
+)
+
+func NewFrontendCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "frontend",
+		Short: "Gerencia o código gerado para o frontend Quasar",
+		Long:  "Comandos para manter o frontend sincronizado com os models e rotas do backend",
+	}
+
+	cmd.AddCommand(newFrontendSyncCommand())
+
+	return cmd
+}
+
+func newFrontendSyncCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Gera interfaces TypeScript, composables e validações para os models",
+		Long: `Lê os models (annotations gaverModel) e as rotas de cada module.go e gera:
+
+  frontend/src/types/<model>.ts          interfaces de leitura e de escrita por método
+  frontend/src/composables/use<Models>.ts composable tipado com list, get, create, update, patch e remove
+  frontend/src/validators/<model>.ts      regras de validação (:rules do Quasar) por método
+
+Os arquivos gerados são sobrescritos a cada execução; arquivos de models removidos são apagados.
+'gaver module crud' executa a sincronização automaticamente em projetos com frontend.`,
+		Example: `  gaver frontend sync`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !frontend.Exists() {
+				return fmt.Errorf("diretório %s não encontrado (o projeto não tem frontend)", frontend.SourceDir)
+			}
+
+			files, err := syncFrontend()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Frontend sincronizado (%d arquivos)\n", len(files))
+			for _, file := range files {
+				fmt.Printf("  - %s\n", file)
+			}
+			return nil
+		},
+	}
+}
+
+func syncFrontend() ([]string, error) {
+	modules, err := openapi.ScanModules("modules")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler módulos: %w", err)
+	}
+
+	files, err := frontend.Sync(modules)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao sincronizar frontend: %w", err)
+	}
+	return files, nil
+}
+
+// syncFrontendAfterChange mantém o frontend em dia depois de gerar ou remover CRUD.
+// Falhas viram aviso: o backend já foi alterado com sucesso.
+func syncFrontendAfterChange() {
+	if !frontend.Exists() {
+		return
+	}
+
+	if _, err := syncFrontend(); err != nil {
+		fmt.Printf("⚠️  Aviso: %v\n", err)
+		fmt.Println("    Rode 'gaver frontend sync' após corrigir o problema")
+		return
+	}
+	fmt.Printf("✓ Tipos e composables atualizados em %s\n", frontend.SourceDir)
+}
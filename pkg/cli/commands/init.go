@@ -98,6 +98,7 @@ func run_init(cmd *cobra.Command, args []string) error {
 	fmt.Println("Próximos passos:")
 	fmt.Printf("  cd %s\n", projectName)
 	fmt.Println("  go mod tidy")
+	fmt.Println("  gaver migrate up")
 
 	// Nota: npm install já foi executado automaticamente para projetos com frontend
 	if projectType == "server" {
@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Dalistor/gaver/pkg/jobs"
+	"github.com/Dalistor/gaver/pkg/migrations"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+func NewJobsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Gerencia a fila de jobs do projeto",
+		Long: `Lista, reenfileira e remove os jobs da fila (config/jobs).
+
+Usa o banco configurado no .env. A tabela jobs é criada pela migration
+create_jobs, gerada com o projeto ('gaver migrate up').`,
+	}
+
+	cmd.AddCommand(newJobsListCommand())
+	cmd.AddCommand(newJobsRetryCommand())
+	cmd.AddCommand(newJobsPurgeCommand())
+
+	return cmd
+}
+
+func newJobsListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lista os jobs",
+		Example: `  gaver jobs list
+  gaver jobs list --status dead
+  gaver jobs list --name send_email --limit 10`,
+		Args: cobra.NoArgs,
+		RunE: runJobsList,
+	}
+
+	cmd.Flags().String("status", "", "Filtrar por status (pending, running, done, dead)")
+	cmd.Flags().String("name", "", "Filtrar pelo nome do job")
+	cmd.Flags().Int("limit", 50, "Número máximo de jobs")
+
+	return cmd
+}
+
+func runJobsList(cmd *cobra.Command, args []string) error {
+	status, _ := cmd.Flags().GetString("status")
+	name, _ := cmd.Flags().GetString("name")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	if status != "" && !validJobStatus(status) {
+		return fmt.Errorf("status inválido: %s (use pending, running, done ou dead)", status)
+	}
+
+	db, err := connectJobsDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	list, err := jobs.List(db, jobs.Filter{Status: status, Name: name, Limit: limit})
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 0 {
+		fmt.Println("Nenhum job encontrado")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNOME\tSTATUS\tTENTATIVAS\tEXECUTAR EM\tCRIADO EM\tÚLTIMO ERRO")
+	for _, job := range list {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d/%d\t%s\t%s\t%s\n",
+			job.ID, job.Name, job.Status, job.Attempts, job.MaxAttempts,
+			formatKeyTime(&job.RunAt), formatKeyTime(&job.CreatedAt), truncate(job.LastError, 60))
+	}
+	return w.Flush()
+}
+
+func newJobsRetryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry [id...]",
+		Short: "Reenfileira jobs dead",
+		Long:  "Devolve jobs com status dead à fila, com as tentativas zeradas.",
+		Example: `  gaver jobs retry 42 43
+  gaver jobs retry --all`,
+		RunE: runJobsRetry,
+	}
+
+	cmd.Flags().Bool("all", false, "Reenfileirar todos os jobs dead")
+
+	return cmd
+}
+
+func runJobsRetry(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	if all == (len(args) > 0) {
+		return fmt.Errorf("informe os IDs dos jobs ou --all")
+	}
+
+	ids := make([]uint, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("ID inválido: %s", arg)
+		}
+		ids = append(ids, uint(id))
+	}
+
+	db, err := connectJobsDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	count, err := jobs.Retry(db, ids)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		fmt.Println("Nenhum job dead encontrado")
+		return nil
+	}
+
+	fmt.Printf("✓ %d job(s) reenfileirado(s)\n", count)
+	return nil
+}
+
+func newJobsPurgeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Remove jobs concluídos ou dead",
+		Example: `  gaver jobs purge
+  gaver jobs purge --status dead --older-than 30d
+  gaver jobs purge --status all --older-than 0s`,
+		Args: cobra.NoArgs,
+		RunE: runJobsPurge,
+	}
+
+	cmd.Flags().String("status", jobs.StatusDone, "Status a remover (done, dead ou all)")
+	cmd.Flags().String("older-than", "7d", "Remover apenas jobs sem atualização há mais tempo que isso (ex: 72h, 30d)")
+
+	return cmd
+}
+
+func runJobsPurge(cmd *cobra.Command, args []string) error {
+	status, _ := cmd.Flags().GetString("status")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+
+	var statuses []string
+	switch status {
+	case jobs.StatusDone, jobs.StatusDead:
+		statuses = []string{status}
+	case "all":
+		statuses = []string{jobs.StatusDone, jobs.StatusDead}
+	default:
+		return fmt.Errorf("status inválido: %s (use done, dead ou all)", status)
+	}
+
+	age := time.Duration(0)
+	if olderThan != "0" && olderThan != "0s" {
+		var err error
+		if age, err = parseDuration(olderThan); err != nil {
+			return err
+		}
+	}
+
+	db, err := connectJobsDB()
+	if err != nil {
+		return err
+	}
+	defer migrations.CloseDB()
+
+	count, err := jobs.Purge(db, statuses, time.Now().Add(-age))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ %d job(s) removido(s)\n", count)
+	return nil
+}
+
+// connectJobsDB conecta ao banco do projeto e verifica a tabela jobs
+func connectJobsDB() (*gorm.DB, error) {
+	db, err := migrations.ConnectDB()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao conectar ao banco: %w", err)
+	}
+
+	if err := jobs.EnsureTable(db); err != nil {
+		migrations.CloseDB()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func validJobStatus(status string) bool {
+	switch status {
+	case jobs.StatusPending, jobs.StatusRunning, jobs.StatusDone, jobs.StatusDead:
+		return true
+	}
+	return false
+}
+
+// truncate limita o texto a n caracteres em uma linha
+func truncate(text string, n int) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	if len([]rune(text)) <= n {
+		return text
+	}
+	return string([]rune(text)[:n-1]) + "…"
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dalistor/gaver/pkg/modules"
+
+	"github.com/spf13/cobra"
+)
+
+func NewMakeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "make",
+		Short: "Gera factories e seeders",
+		Long:  "Gera factories de models (modules/<módulo>/factories) e seeders (seeders/).",
+	}
+
+	cmd.AddCommand(newMakeFactoryCommand())
+	cmd.AddCommand(newMakeSeederCommand())
+
+	return cmd
+}
+
+func newMakeFactoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "factory [module] [ModelName]",
+		Short: "Gera a factory de um model",
+		Long: `Gera modules/<módulo>/factories/<model>_factory.go, que cria registros
+válidos a partir das annotations do model (enum, min/max, minLength/maxLength,
+email, url, unique).
+
+Relações belongsTo obrigatórias são criadas com a factory do model
+relacionado, gerada também se ainda não existir.`,
+		Example: `  gaver make factory shop Product
+  gaver make factory shop Product --force`,
+		Args: cobra.ExactArgs(2),
+		RunE: runMakeFactory,
+	}
+
+	cmd.Flags().Bool("force", false, "Sobrescrever a factory existente")
+
+	return cmd
+}
+
+func runMakeFactory(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	files, err := modules.CreateFactory(args[0], args[1], force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Factory de %s gerada com sucesso!\n\n", args[1])
+	fmt.Println("Arquivos criados:")
+	for _, file := range files {
+		fmt.Printf("  - %s\n", file)
+	}
+	fmt.Println("\nUso:")
+	fmt.Printf("  %sfactories.%s.Create(ctx)         // grava um registro\n", args[0], args[1])
+	fmt.Printf("  %sfactories.%s.CreateMany(ctx, 10) // grava 10 registros\n", args[0], args[1])
+	fmt.Printf("  %sfactories.%s.Make()              // apenas monta o struct\n", args[0], args[1])
+	fmt.Println("\nRevise os campos marcados com TODO.")
+
+	return nil
+}
+
+func newMakeSeederCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "seeder [nome]",
+		Short: "Gera um seeder",
+		Long: `Cria seeders/<nome>.go e o registra ao final de RegisterDefaultSeeders
+(seeders/seeders.go), que define a ordem de execução de 'gaver db seed'.
+
+Com --factory, o seeder cria --count registros com a factory do model,
+gerada se ainda não existir.`,
+		Example: `  gaver make seeder products --factory shop.Product --count 50
+  gaver make seeder demo_users`,
+		Args: cobra.ExactArgs(1),
+		RunE: runMakeSeeder,
+	}
+
+	cmd.Flags().String("factory", "", "Model populado pelo seeder, no formato modulo.Model")
+	cmd.Flags().Int("count", 10, "Número de registros criados com --factory")
+
+	return cmd
+}
+
+func runMakeSeeder(cmd *cobra.Command, args []string) error {
+	factory, _ := cmd.Flags().GetString("factory")
+	count, _ := cmd.Flags().GetInt("count")
+
+	var opts modules.SeederOptions
+	if factory != "" {
+		module, model, found := strings.Cut(factory, ".")
+		if !found || module == "" || model == "" {
+			return fmt.Errorf("--factory inválido: %s (use modulo.Model, ex: shop.Product)", factory)
+		}
+		if count < 1 {
+			return fmt.Errorf("--count deve ser maior que zero")
+		}
+		opts = modules.SeederOptions{Module: module, Model: model, Count: count}
+	}
+
+	files, err := modules.CreateSeeder(args[0], opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Seeder '%s' criado com sucesso!\n\n", args[0])
+	fmt.Println("Arquivos criados:")
+	for _, file := range files {
+		fmt.Printf("  - %s\n", file)
+	}
+	fmt.Println("\nArquivos atualizados:")
+	fmt.Println("  - seeders/seeders.go (RegisterDefaultSeeders)")
+	fmt.Println("\nPróximos passos:")
+	if factory == "" {
+		fmt.Println("  1. Implemente o seeder no TODO gerado")
+		fmt.Printf("  2. gaver db seed --class %s\n", args[0])
+	} else {
+		fmt.Printf("  gaver db seed --class %s\n", args[0])
+	}
+
+	return nil
+}
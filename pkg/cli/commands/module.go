@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/Dalistor/gaver/pkg/generator"
 	"github.com/Dalistor/gaver/pkg/modules"
 
 	"github.com/spf13/cobra"
@@ -19,6 +23,7 @@ func NewModuleCommand() *cobra.Command {
 	cmd.AddCommand(newModuleCreateCommand())
 	cmd.AddCommand(newModuleModelCommand())
 	cmd.AddCommand(newModuleCrudCommand())
+	cmd.AddCommand(newModuleRemoveCommand())
 
 	return cmd
 }
@@ -60,28 +65,38 @@ func runModuleCreate(cmd *cobra.Command, args []string) error {
 }
 
 func newModuleModelCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "model [module] [ModelName]",
 		Short: "Cria um model template dentro de um módulo",
 		Long:  "Gera um arquivo de model template com comentários explicativos sobre annotations gaverModel.",
 		Example: `  gaver module model users User
-  gaver module model products Product`,
+  gaver module model products Product
+  gaver module model products Product --remove`,
 		Args: cobra.ExactArgs(2),
 		RunE: runModuleModel,
 	}
+
+	cmd.Flags().Bool("remove", false, "Remove o model e o CRUD gerado para ele")
+	cmd.Flags().BoolP("yes", "y", false, "Não pede confirmação ao remover")
+
+	return cmd
 }
 
 func runModuleModel(cmd *cobra.Command, args []string) error {
 	moduleName := args[0]
 	modelName := args[1]
 
+	if remove, _ := cmd.Flags().GetBool("remove"); remove {
+		return runModuleModelRemove(cmd, moduleName, modelName)
+	}
+
 	fmt.Printf("Gerando model template '%s' no módulo '%s'...\n", modelName, moduleName)
 
 	if err := modules.CreateModelTemplate(moduleName, modelName); err != nil {
 		return fmt.Errorf("erro ao criar model: %w", err)
 	}
 
-	fmt.Printf("✓ Model template '%s' criado em modules/%s/models/%s.go\n", modelName, moduleName, toLower(modelName))
+	fmt.Printf("✓ Model template '%s' criado em modules/%s/models/%s.go\n", modelName, moduleName, generator.ToSnakeCase(modelName))
 	fmt.Println("\n📝 Próximos passos:")
 	fmt.Println("  1. Edite o arquivo e adicione seus campos")
 	fmt.Println("  2. Preencha as annotations gaverModel conforme necessário")
@@ -101,6 +116,8 @@ func newModuleCrudCommand() *cobra.Command {
 
 	cmd.Flags().StringSlice("only", []string{}, "Gera apenas os métodos especificados (list,get,create,update,delete)")
 	cmd.Flags().StringSlice("except", []string{}, "Gera todos exceto os métodos especificados")
+	cmd.Flags().Bool("remove", false, "Remove handler, service, repository e rotas do model")
+	cmd.Flags().BoolP("yes", "y", false, "Não pede confirmação ao remover")
 
 	return cmd
 }
@@ -109,6 +126,10 @@ func runModuleCrud(cmd *cobra.Command, args []string) error {
 	moduleName := args[0]
 	modelName := args[1]
 
+	if remove, _ := cmd.Flags().GetBool("remove"); remove {
+		return runModuleCrudRemove(cmd, moduleName, modelName)
+	}
+
 	only, _ := cmd.Flags().GetStringSlice("only")
 	except, _ := cmd.Flags().GetStringSlice("except")
 
@@ -120,16 +141,123 @@ func runModuleCrud(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("✓ CRUD gerado com sucesso!\n\n")
 	fmt.Println("Arquivos criados:")
-	fmt.Printf("  - modules/%s/handlers/%s_handler.go\n", moduleName, toLower(modelName))
-	fmt.Printf("  - modules/%s/services/%s_service.go\n", moduleName, toLower(modelName))
-	fmt.Printf("  - modules/%s/repositories/%s_repository.go\n", moduleName, toLower(modelName))
+	fmt.Printf("  - modules/%s/handlers/%s_handler.go\n", moduleName, generator.ToSnakeCase(modelName))
+	fmt.Printf("  - modules/%s/handlers/%s_handler_test.go\n", moduleName, generator.ToSnakeCase(modelName))
+	fmt.Printf("  - modules/%s/services/%s_service.go\n", moduleName, generator.ToSnakeCase(modelName))
+	fmt.Printf("  - modules/%s/repositories/%s_repository.go\n", moduleName, generator.ToSnakeCase(modelName))
+	fmt.Printf("  - modules/%s/repositories/mocks/%s_repository.go\n", moduleName, generator.ToSnakeCase(modelName))
+
+	syncFrontendAfterChange()
+
+	return nil
+}
+
+func runModuleCrudRemove(cmd *cobra.Command, moduleName, modelName string) error {
+	files := modules.CRUDFiles(moduleName, modelName)
 
+	fmt.Printf("Removendo CRUD de '%s' no módulo '%s':\n", modelName, moduleName)
+	for _, file := range files {
+		fmt.Printf("  - %s\n", file)
+	}
+	fmt.Printf("  - rotas de %s em modules/%s/module.go\n", modelName, moduleName)
+
+	if !confirmRemoval(cmd) {
+		fmt.Println("Operação cancelada")
+		return nil
+	}
+
+	if err := modules.RemoveCRUD(moduleName, modelName); err != nil {
+		return fmt.Errorf("erro ao remover CRUD: %w", err)
+	}
+
+	fmt.Printf("✓ CRUD de '%s' removido com sucesso!\n", modelName)
+	syncFrontendAfterChange()
 	return nil
 }
 
-func toLower(s string) string {
-	if len(s) == 0 {
-		return s
+func runModuleModelRemove(cmd *cobra.Command, moduleName, modelName string) error {
+	fmt.Printf("Removendo model '%s' do módulo '%s':\n", modelName, moduleName)
+	fmt.Printf("  - modules/%s/models/%s.go\n", moduleName, generator.ToSnakeCase(modelName))
+	for _, file := range modules.CRUDFiles(moduleName, modelName) {
+		fmt.Printf("  - %s\n", file)
+	}
+	fmt.Printf("  - modules/%s/factories/%s_factory.go\n", moduleName, generator.ToSnakeCase(modelName))
+	for _, file := range modules.FactorySeeders(moduleName, modelName) {
+		fmt.Printf("  - %s (e o registro em seeders/seeders.go)\n", file)
+	}
+
+	if !confirmRemoval(cmd) {
+		fmt.Println("Operação cancelada")
+		return nil
+	}
+
+	if err := modules.RemoveModel(moduleName, modelName); err != nil {
+		return fmt.Errorf("erro ao remover model: %w", err)
 	}
-	return string(s[0]+32) + s[1:]
+
+	fmt.Printf("✓ Model '%s' removido com sucesso!\n", modelName)
+	syncFrontendAfterChange()
+	fmt.Println("\n📝 Lembre-se de gerar uma migration para a tabela removida:")
+	fmt.Println("  gaver makemigrations")
+
+	return nil
+}
+
+func newModuleRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove [nome]",
+		Short: "Remove um módulo",
+		Long:  "Remove a pasta do módulo e o seu registro (import e registry.Register) em config/modules/modules.go.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runModuleRemove,
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "Não pede confirmação")
+
+	return cmd
+}
+
+func runModuleRemove(cmd *cobra.Command, args []string) error {
+	moduleName := args[0]
+
+	fmt.Printf("Removendo módulo '%s':\n", moduleName)
+	fmt.Printf("  - modules/%s/ (todos os arquivos)\n", moduleName)
+	fmt.Println("  - registro em config/modules/modules.go")
+	for _, file := range modules.FactorySeeders(moduleName, "") {
+		fmt.Printf("  - %s (e o registro em seeders/seeders.go)\n", file)
+	}
+
+	if !confirmRemoval(cmd) {
+		fmt.Println("Operação cancelada")
+		return nil
+	}
+
+	if err := modules.RemoveModule(moduleName); err != nil {
+		return fmt.Errorf("erro ao remover módulo: %w", err)
+	}
+
+	fmt.Printf("✓ Módulo '%s' removido com sucesso!\n", moduleName)
+	syncFrontendAfterChange()
+	return nil
+}
+
+// confirmRemoval pede confirmação no terminal, a menos que --yes tenha sido usado
+func confirmRemoval(cmd *cobra.Command) bool {
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return true
+	}
+	return confirm("Confirmar remoção?")
+}
+
+// confirm pergunta sim/não no terminal
+func confirm(question string) bool {
+	fmt.Printf("%s [s/N]: ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "s" || answer == "sim" || answer == "y" || answer == "yes"
 }
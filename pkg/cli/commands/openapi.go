@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Dalistor/gaver/pkg/config"
+	"github.com/Dalistor/gaver/pkg/openapi"
+
+	"github.com/spf13/cobra"
+)
+
+func NewOpenAPICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Gera a especificação OpenAPI 3.1 da API",
+		Long: `Lê os models (annotations gaverModel) e as rotas registradas em cada module.go
+e gera um documento OpenAPI 3.1 com schemas por método, paginação, filtros e respostas de erro.
+
+Por padrão o documento é gravado em config/docs/openapi.json, que é embarcado no
+servidor e exposto em GET /api/docs quando API_DOCS_ENABLED=true.`,
+		Example: `  gaver openapi
+  gaver openapi -o openapi.json --version 2.0.0`,
+		RunE: runOpenAPI,
+	}
+
+	cmd.Flags().StringP("output", "o", openapi.DefaultOutput, "Arquivo de saída")
+	cmd.Flags().String("version", "1.0.0", "Versão da API (info.version)")
+
+	return cmd
+}
+
+func runOpenAPI(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	version, _ := cmd.Flags().GetString("version")
+
+	projectName, err := config.ReadModuleName()
+	if err != nil {
+		return fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	fmt.Println("Lendo models e rotas dos módulos...")
+
+	modules, err := openapi.ScanModules("modules")
+	if err != nil {
+		return fmt.Errorf("erro ao ler módulos: %w", err)
+	}
+
+	doc := openapi.NewBuilder(projectName, version).Build(modules)
+
+	if err := openapi.WriteDocument(doc, output); err != nil {
+		return fmt.Errorf("erro ao gravar documento: %w", err)
+	}
+
+	fmt.Printf("✓ Documento OpenAPI gerado: %s (%d paths, %d schemas)\n", output, len(doc.Paths), len(doc.Components.Schemas))
+
+	// Projetos antigos não têm o pacote que serve /api/docs
+	if output == openapi.DefaultOutput {
+		created, err := openapi.EnsureDocsPackage(projectName)
+		if err != nil {
+			return fmt.Errorf("erro ao gerar %s: %w", openapi.DocsPackageFile, err)
+		}
+		if created {
+			fmt.Printf("✓ Pacote %s criado\n", openapi.DocsPackageFile)
+			fmt.Println("\n📝 Para servir o documento em /api/docs, adicione em cmd/server/main.go:")
+			fmt.Printf("  import \"%s/config/docs\"\n", projectName)
+			fmt.Println("  docs.Register(router)")
+			fmt.Println("  # e defina API_DOCS_ENABLED=true no .env")
+		}
+	}
+
+	return nil
+}
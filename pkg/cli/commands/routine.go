@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Dalistor/gaver/pkg/routines"
+
+	"github.com/spf13/cobra"
+)
+
+func NewRoutineCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "routine",
+		Short: "Gerencia as rotinas do projeto",
+		Long:  "Gera rotinas em config/routines/routines.go (função RegisterDefaultRoutines).",
+	}
+
+	cmd.AddCommand(newRoutineCreateCommand())
+
+	return cmd
+}
+
+func newRoutineCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [nome]",
+		Short: "Cria o esqueleto de uma rotina",
+		Long: `Adiciona uma rotina em RegisterDefaultRoutines, agendada por intervalo
+(--every) ou por expressão cron (--cron).`,
+		Example: `  gaver routine create send_reports --every 15m
+  gaver routine create daily_cleanup --cron "0 3 * * *" --timeout 10m
+  gaver routine create warm_cache --every 1h --initial-run --without-lock`,
+		Args: cobra.ExactArgs(1),
+		RunE: runRoutineCreate,
+	}
+
+	cmd.Flags().String("every", "1h", "Intervalo entre execuções (ex: 30s, 15m, 1h, 1d)")
+	cmd.Flags().String("cron", "", "Expressão cron (substitui --every)")
+	cmd.Flags().String("timeout", "", "Tempo máximo de cada execução (ex: 5m)")
+	cmd.Flags().Bool("without-lock", false, "Executar em todas as instâncias (sem lock distribuído)")
+	cmd.Flags().Bool("initial-run", false, "Executar também ao iniciar o servidor")
+
+	return cmd
+}
+
+func runRoutineCreate(cmd *cobra.Command, args []string) error {
+	every, _ := cmd.Flags().GetString("every")
+	cron, _ := cmd.Flags().GetString("cron")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	withoutLock, _ := cmd.Flags().GetBool("without-lock")
+	initialRun, _ := cmd.Flags().GetBool("initial-run")
+
+	opts := routines.Options{
+		Cron:        cron,
+		WithoutLock: withoutLock,
+		InitialRun:  initialRun,
+	}
+
+	if cron == "" {
+		interval, err := parseDuration(every)
+		if err != nil {
+			return fmt.Errorf("--every inválido: %w", err)
+		}
+		opts.Every = interval
+	}
+
+	if timeout != "" {
+		duration, err := parseDuration(timeout)
+		if err != nil {
+			return fmt.Errorf("--timeout inválido: %w", err)
+		}
+		opts.Timeout = duration
+	}
+
+	if err := routines.Create(args[0], opts); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Rotina '%s' criada em config/routines/routines.go\n", args[0])
+	fmt.Println("  Implemente a tarefa no TODO gerado.")
+
+	return nil
+}
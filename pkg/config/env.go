@@ -0,0 +1,63 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvVar representa uma variável de um arquivo .env
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// GenerateSecret gera um segredo aleatório de 256 bits em hexadecimal
+func GenerateSecret() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("erro ao gerar segredo: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// AppendEnvVars adiciona ao arquivo (.env, .env.example) as variáveis que ainda
+// não estão definidas, em um bloco precedido pelo comentário. Arquivos que não
+// existem são ignorados. Retorna as chaves adicionadas.
+func AppendEnvVars(path, comment string, vars []EnvVar) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+
+	defined := map[string]bool{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if key, _, ok := strings.Cut(strings.TrimSpace(line), "="); ok && !strings.HasPrefix(key, "#") {
+			defined[strings.TrimSpace(key)] = true
+		}
+	}
+
+	var block strings.Builder
+	var added []string
+	for _, v := range vars {
+		if defined[v.Key] {
+			continue
+		}
+		block.WriteString(v.Key + "=" + v.Value + "\n")
+		added = append(added, v.Key)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	content := strings.TrimRight(string(data), "\n") + "\n\n# " + comment + "\n" + block.String()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+	return added, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // ProjectType representa o tipo de projeto
@@ -70,3 +71,19 @@ func IsValidProjectType(projectType string) bool {
 		return false
 	}
 }
+
+// ReadModuleName lê o nome do módulo Go (linha "module") do go.mod do diretório atual
+func ReadModuleName() (string, error) {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler go.mod: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+		}
+	}
+
+	return "", fmt.Errorf("nome do módulo não encontrado no go.mod")
+}
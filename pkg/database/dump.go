@@ -0,0 +1,373 @@
+package database
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// Format é o formato do arquivo de dump
+type Format string
+
+const (
+	// FormatJSON é portável entre bancos: os valores são convertidos para os
+	// tipos das colunas de destino no restore
+	FormatJSON Format = "json"
+	// FormatSQL gera INSERTs no dialeto do banco de origem
+	FormatSQL Format = "sql"
+)
+
+// DumpVersion é a versão do formato JSON gerado
+const DumpVersion = 1
+
+// batchSize é o número de registros por INSERT (SQL) ou lote de inserção
+const batchSize = 100
+
+// DumpOptions configura o dump
+type DumpOptions struct {
+	Format  Format
+	Tables  []string // apenas estas tabelas (vazio = todas)
+	Exclude []string // tabelas ignoradas
+}
+
+// TableCount é o número de registros de uma tabela no dump ou restore
+type TableCount struct {
+	Table string
+	Rows  int
+}
+
+// FormatFromPath deduz o formato pela extensão do arquivo (json por padrão)
+func FormatFromPath(path string) Format {
+	if strings.HasSuffix(strings.ToLower(path), ".sql") {
+		return FormatSQL
+	}
+	return FormatJSON
+}
+
+// ParseFormat valida o nome do formato
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatSQL:
+		return FormatSQL, nil
+	}
+	return "", fmt.Errorf("formato inválido: %s (use json ou sql)", name)
+}
+
+// Dump escreve os dados das tabelas em w, na ordem das chaves estrangeiras.
+// Apenas os dados são exportados: o schema vem das migrations.
+func Dump(db *gorm.DB, w io.Writer, opts DumpOptions) ([]TableCount, error) {
+	tables, err := selectTables(db, opts.Tables, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	out := bufio.NewWriter(w)
+	var writer tableWriter
+	switch opts.Format {
+	case FormatSQL:
+		writer = &sqlWriter{out: out, db: db}
+	case FormatJSON, "":
+		writer = &jsonWriter{out: out}
+	default:
+		return nil, fmt.Errorf("formato inválido: %s (use json ou sql)", opts.Format)
+	}
+
+	if err := writer.begin(db.Dialector.Name()); err != nil {
+		return nil, fmt.Errorf("erro ao escrever dump: %w", err)
+	}
+
+	counts := make([]TableCount, 0, len(tables))
+	for i, table := range tables {
+		count, err := dumpTable(db, table, i, writer)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, TableCount{Table: table, Rows: count})
+	}
+
+	if err := writer.end(); err != nil {
+		return nil, fmt.Errorf("erro ao escrever dump: %w", err)
+	}
+	if err := out.Flush(); err != nil {
+		return nil, fmt.Errorf("erro ao escrever dump: %w", err)
+	}
+	return counts, nil
+}
+
+// dumpTable lê os registros da tabela e os repassa ao writer
+func dumpTable(db *gorm.DB, table string, index int, writer tableWriter) (int, error) {
+	rows, err := db.Table(table).Rows()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao ler tabela %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("erro ao ler colunas de %s: %w", table, err)
+	}
+	if err := writer.beginTable(index, table, columns); err != nil {
+		return 0, fmt.Errorf("erro ao escrever dump: %w", err)
+	}
+
+	count := 0
+	for rows.Next() {
+		values, err := scanRow(rows, len(columns))
+		if err != nil {
+			return 0, fmt.Errorf("erro ao ler registro de %s: %w", table, err)
+		}
+		if err := writer.row(count, values); err != nil {
+			return 0, fmt.Errorf("erro ao escrever dump: %w", err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("erro ao ler tabela %s: %w", table, err)
+	}
+
+	if err := writer.endTable(count); err != nil {
+		return 0, fmt.Errorf("erro ao escrever dump: %w", err)
+	}
+	return count, nil
+}
+
+// scanRow lê os valores do registro atual com os tipos do driver
+func scanRow(rows *sql.Rows, size int) ([]interface{}, error) {
+	values := make([]interface{}, size)
+	pointers := make([]interface{}, size)
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := rows.Scan(pointers...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// tableWriter escreve um formato de dump tabela a tabela
+type tableWriter interface {
+	begin(driver string) error
+	beginTable(index int, table string, columns []string) error
+	row(index int, values []interface{}) error
+	endTable(count int) error
+	end() error
+}
+
+// jsonDump é a estrutura do dump JSON
+type jsonDump struct {
+	Version   int         `json:"version"`
+	Driver    string      `json:"driver"`
+	CreatedAt time.Time   `json:"created_at"`
+	Tables    []jsonTable `json:"tables"`
+}
+
+type jsonTable struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// jsonWriter escreve o dump JSON em streaming, um registro por linha
+type jsonWriter struct {
+	out *bufio.Writer
+}
+
+func (w *jsonWriter) begin(driver string) error {
+	header, err := json.MarshalIndent(struct {
+		Version   int       `json:"version"`
+		Driver    string    `json:"driver"`
+		CreatedAt time.Time `json:"created_at"`
+	}{DumpVersion, driver, time.Now().Truncate(time.Second)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Reabre o objeto para acrescentar as tabelas
+	_, err = fmt.Fprintf(w.out, "%s,\n  \"tables\": [", strings.TrimSuffix(string(header), "\n}"))
+	return err
+}
+
+func (w *jsonWriter) beginTable(index int, table string, columns []string) error {
+	name, err := json.Marshal(table)
+	if err != nil {
+		return err
+	}
+	cols, err := json.Marshal(columns)
+	if err != nil {
+		return err
+	}
+	if index > 0 {
+		w.out.WriteString(",")
+	}
+	_, err = fmt.Fprintf(w.out, "\n    {\n      \"name\": %s,\n      \"columns\": %s,\n      \"rows\": [", name, cols)
+	return err
+}
+
+func (w *jsonWriter) row(index int, values []interface{}) error {
+	for i, value := range values {
+		values[i] = jsonValue(value)
+	}
+	line, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if index > 0 {
+		w.out.WriteString(",")
+	}
+	w.out.WriteString("\n        ")
+	_, err = w.out.Write(line)
+	return err
+}
+
+func (w *jsonWriter) endTable(count int) error {
+	if count > 0 {
+		w.out.WriteString("\n      ")
+	}
+	_, err := w.out.WriteString("]\n    }")
+	return err
+}
+
+func (w *jsonWriter) end() error {
+	_, err := w.out.WriteString("\n  ]\n}\n")
+	return err
+}
+
+// jsonValue converte o valor lido do banco para JSON: bytes viram texto (ou
+// {"base64": ...} se não forem UTF-8) e datas, RFC 3339
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return map[string]string{"base64": base64.StdEncoding.EncodeToString(v)}
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return value
+}
+
+// sqlWriter escreve INSERTs de até batchSize registros
+type sqlWriter struct {
+	out     *bufio.Writer
+	db      *gorm.DB
+	insert  string
+	pending int
+}
+
+func (w *sqlWriter) begin(driver string) error {
+	_, err := fmt.Fprintf(w.out, "-- Dump de dados gerado pelo Gaver (%s) em %s\n-- Restaure com: gaver db restore <arquivo>\n",
+		driver, time.Now().Format(time.RFC3339))
+	return err
+}
+
+func (w *sqlWriter) beginTable(index int, table string, columns []string) error {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = w.quote(column)
+	}
+	w.insert = "INSERT INTO " + w.quote(table) + " (" + strings.Join(quoted, ", ") + ") VALUES"
+	w.pending = 0
+
+	_, err := fmt.Fprintf(w.out, "\n-- Tabela %s\n", table)
+	return err
+}
+
+func (w *sqlWriter) row(index int, values []interface{}) error {
+	if w.pending == batchSize {
+		w.out.WriteString(";\n")
+		w.pending = 0
+	}
+	if w.pending == 0 {
+		w.out.WriteString(w.insert)
+	} else {
+		w.out.WriteString(",")
+	}
+
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = sqlLiteral(w.db.Dialector.Name(), value)
+	}
+	_, err := w.out.WriteString("\n  (" + strings.Join(literals, ", ") + ")")
+	w.pending++
+	return err
+}
+
+func (w *sqlWriter) endTable(count int) error {
+	if w.pending > 0 {
+		_, err := w.out.WriteString(";\n")
+		return err
+	}
+	return nil
+}
+
+func (w *sqlWriter) end() error {
+	return nil
+}
+
+// quote escapa o identificador no dialeto do banco
+func (w *sqlWriter) quote(name string) string {
+	var builder strings.Builder
+	w.db.Dialector.QuoteTo(&builder, name)
+	return builder.String()
+}
+
+// sqlLiteral escreve o valor como literal SQL do driver
+func sqlLiteral(driver string, value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int:
+		return strconv.Itoa(v)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case time.Time:
+		if driver == "mysql" {
+			return "'" + v.UTC().Format("2006-01-02 15:04:05.999999") + "'"
+		}
+		return "'" + v.Format("2006-01-02 15:04:05.999999999-07:00") + "'"
+	case []byte:
+		if utf8.Valid(v) {
+			return quoteString(driver, string(v))
+		}
+		if driver == "postgres" {
+			return `'\x` + hex.EncodeToString(v) + "'"
+		}
+		return "X'" + hex.EncodeToString(v) + "'"
+	case string:
+		return quoteString(driver, v)
+	}
+	return quoteString(driver, fmt.Sprint(value))
+}
+
+// quoteString escapa aspas (e barras invertidas no MySQL)
+func quoteString(driver, value string) string {
+	if driver == "mysql" {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,165 @@
+package database_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/database"
+	"github.com/Dalistor/gaver/pkg/migrations"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// productsMigration é a migration de um módulo do projeto
+const productsMigration = `-- ========== UP ==========
+CREATE TABLE products (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    price REAL,
+    created_at DATETIME
+);
+
+-- ========== DOWN ==========
+DROP TABLE IF EXISTS products;
+`
+
+// setupProject cria migrations/ no diretório do teste com as migrations
+// geradas pelo gaver init (tabelas de config/) e a de um módulo, e abre um
+// SQLite vazio como banco das migrations
+func setupProject(t *testing.T) *gorm.DB {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	if err := os.Mkdir("migrations", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := fs.Glob(templates.TemplatesFS, "migration_create_*.tmpl")
+	if err != nil || len(names) == 0 {
+		t.Fatalf("templates de migration não encontrados: %v", err)
+	}
+	data := struct{ DatabaseDriver string }{"sqlite"}
+	for i, name := range names {
+		content, err := templates.Render(name, data)
+		if err != nil {
+			t.Fatalf("erro ao renderizar %s: %v", name, err)
+		}
+		table := strings.TrimSuffix(strings.TrimPrefix(name, "migration_create_"), ".tmpl")
+		file := filepath.Join("migrations", fmt.Sprintf("20240101_%06d_create_%s.sql", i, table))
+		if err := os.WriteFile(file, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join("migrations", "20240102_000000_create_products.sql"), []byte(productsMigration), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gorm.Open(sqlite.Open("test.db"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("erro ao abrir banco: %v", err)
+	}
+	t.Cleanup(func() {
+		migrations.DB = nil
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrations.DB = db
+	migrateUp(t)
+	return db
+}
+
+func migrateUp(t *testing.T) {
+	t.Helper()
+	if _, err := migrations.NewRunner().MigrateUp(0); err != nil {
+		t.Fatalf("erro ao aplicar migrations: %v", err)
+	}
+}
+
+// seed grava registros nas tabelas do módulo e nas que o servidor usa em
+// execução (rotinas, jobs, rate limit)
+func seed(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	statements := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{"INSERT INTO products (name, price, created_at) VALUES (?, ?, ?), (?, ?, ?)",
+			[]interface{}{"Caneta", 2.5, now, "Caderno", 12.9, now}},
+		{`INSERT INTO routine_executions (routine, "trigger", status, instance, started_at, duration_ms) VALUES (?, ?, ?, ?, ?, ?)`,
+			[]interface{}{"send_reports", "scheduled", "success", "host-1-abcd", now, 120}},
+		{"INSERT INTO routine_locks (name, owner, last_slot, acquired_at, expires_at) VALUES (?, ?, ?, ?, ?)",
+			[]interface{}{"send_reports", "host-1-abcd", now.Unix(), now, now.Add(time.Minute)}},
+		{"INSERT INTO jobs (name, payload, status, attempts, max_attempts, run_at) VALUES (?, ?, ?, ?, ?, ?)",
+			[]interface{}{"send_email", `{"to":"ana@example.com"}`, "pending", 0, 5, now}},
+		{"INSERT INTO rate_limit_counters (bucket_key, window_start, hits, expires_at) VALUES (?, ?, ?, ?)",
+			[]interface{}{"ip:127.0.0.1", now, 3, now.Add(time.Minute)}},
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt.sql, stmt.args...).Error; err != nil {
+			t.Fatalf("erro ao inserir dados: %v", err)
+		}
+	}
+}
+
+// O fluxo documentado: gaver db dump, gaver db wipe -y, gaver migrate up e
+// gaver db restore devolve todos os registros
+func TestDumpWipeMigrateRestore(t *testing.T) {
+	for _, format := range []database.Format{database.FormatJSON, database.FormatSQL} {
+		t.Run(string(format), func(t *testing.T) {
+			db := setupProject(t)
+			seed(t, db)
+
+			var dump bytes.Buffer
+			dumped, err := database.Dump(db, &dump, database.DumpOptions{Format: format})
+			if err != nil {
+				t.Fatalf("erro no dump: %v", err)
+			}
+			tables := make([]string, len(dumped))
+			for i, count := range dumped {
+				tables[i] = count.Table
+			}
+			for _, table := range []string{"products", "routine_executions", "routine_locks", "jobs", "rate_limit_counters"} {
+				if !slices.Contains(tables, table) {
+					t.Errorf("tabela %s fora do dump: %v", table, tables)
+				}
+			}
+
+			if _, err := database.Wipe(db); err != nil {
+				t.Fatalf("erro no wipe: %v", err)
+			}
+			migrateUp(t)
+
+			restored, err := database.Restore(db, &dump, database.RestoreOptions{Format: format})
+			if err != nil {
+				t.Fatalf("erro no restore: %v", err)
+			}
+			if !slices.Equal(restored, dumped) {
+				t.Errorf("restore = %v, dump = %v", restored, dumped)
+			}
+
+			for _, count := range dumped {
+				var rows int64
+				if err := db.Table(count.Table).Count(&rows).Error; err != nil {
+					t.Fatalf("erro ao contar %s: %v", count.Table, err)
+				}
+				if int(rows) != count.Rows {
+					t.Errorf("%s: %d registros, esperado %d", count.Table, rows, count.Rows)
+				}
+			}
+		})
+	}
+}
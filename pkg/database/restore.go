@@ -0,0 +1,299 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RestoreOptions configura o restore
+type RestoreOptions struct {
+	Format Format
+	Clean  bool // apaga os registros das tabelas do dump antes de inserir
+}
+
+// insertTable captura a tabela dos INSERTs do dump SQL
+var insertTable = regexp.MustCompile("(?i)^INSERT\\s+INTO\\s+[\"`]?([^\"`\\s(]+)")
+
+// Restore insere os dados do dump em uma única transação. As tabelas já
+// devem existir (gaver migrate up); em caso de erro nada é alterado.
+func Restore(db *gorm.DB, r io.Reader, opts RestoreOptions) ([]TableCount, error) {
+	var counts []TableCount
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		switch opts.Format {
+		case FormatSQL:
+			counts, err = restoreSQL(tx, r, opts.Clean)
+		case FormatJSON, "":
+			counts, err = restoreJSON(tx, r, opts.Clean)
+		default:
+			err = fmt.Errorf("formato inválido: %s (use json ou sql)", opts.Format)
+		}
+		if err != nil {
+			return err
+		}
+
+		tables := make([]string, len(counts))
+		for i, count := range counts {
+			tables[i] = count.Table
+		}
+		return resetSequences(tx, tables)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// restoreJSON insere as tabelas do dump JSON convertendo os valores para os
+// tipos das colunas de destino
+func restoreJSON(tx *gorm.DB, r io.Reader, clean bool) ([]TableCount, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var dump jsonDump
+	if err := decoder.Decode(&dump); err != nil {
+		return nil, fmt.Errorf("erro ao ler dump JSON: %w", err)
+	}
+	if dump.Version > DumpVersion {
+		return nil, fmt.Errorf("dump na versão %d não suportada (máximo %d): atualize o gaver", dump.Version, DumpVersion)
+	}
+
+	tables := make([]string, len(dump.Tables))
+	for i, table := range dump.Tables {
+		tables[i] = table.Name
+	}
+	if err := checkTables(tx, tables); err != nil {
+		return nil, err
+	}
+	if clean {
+		if err := cleanTables(tx, tables); err != nil {
+			return nil, err
+		}
+	}
+
+	counts := make([]TableCount, 0, len(dump.Tables))
+	for _, table := range dump.Tables {
+		kinds, err := columnKinds(tx, table.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]map[string]interface{}, 0, len(table.Rows))
+		for _, row := range table.Rows {
+			if len(row) != len(table.Columns) {
+				return nil, fmt.Errorf("registro de %s com %d valores para %d colunas", table.Name, len(row), len(table.Columns))
+			}
+			record := make(map[string]interface{}, len(row))
+			for i, column := range table.Columns {
+				value, err := restoreValue(row[i], kinds[column])
+				if err != nil {
+					return nil, fmt.Errorf("erro na coluna %s de %s: %w", column, table.Name, err)
+				}
+				record[column] = value
+			}
+			records = append(records, record)
+		}
+
+		for start := 0; start < len(records); start += batchSize {
+			batch := records[start:min(start+batchSize, len(records))]
+			if err := tx.Table(table.Name).Create(&batch).Error; err != nil {
+				return nil, fmt.Errorf("erro ao inserir em %s: %w", table.Name, err)
+			}
+		}
+		counts = append(counts, TableCount{Table: table.Name, Rows: len(records)})
+	}
+	return counts, nil
+}
+
+// columnKind é o tipo de coluna que exige conversão do valor JSON
+type columnKind int
+
+const (
+	kindOther columnKind = iota
+	kindTime
+	kindBool
+)
+
+// columnKinds classifica as colunas da tabela pelo tipo no banco
+func columnKinds(tx *gorm.DB, table string) (map[string]columnKind, error) {
+	columns, err := tx.Migrator().ColumnTypes(table)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler colunas de %s: %w", table, err)
+	}
+
+	kinds := make(map[string]columnKind, len(columns))
+	for _, column := range columns {
+		name := strings.ToLower(column.DatabaseTypeName())
+		switch {
+		case strings.Contains(name, "date"), strings.Contains(name, "time"):
+			kinds[column.Name()] = kindTime
+		case name == "bool" || name == "boolean":
+			kinds[column.Name()] = kindBool
+		}
+	}
+	return kinds, nil
+}
+
+// restoreValue converte o valor JSON: números inteiros viram int64, datas
+// em RFC 3339 viram time.Time e {"base64": ...}, bytes
+func restoreValue(value interface{}, kind columnKind) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			if kind == kindBool {
+				return n != 0, nil
+			}
+			return n, nil
+		}
+		return v.Float64()
+
+	case string:
+		if kind == kindTime {
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return t, nil
+			}
+		}
+		return v, nil
+
+	case map[string]interface{}:
+		encoded, ok := v["base64"].(string)
+		if !ok {
+			return nil, fmt.Errorf("objeto inesperado no dump")
+		}
+		return base64.StdEncoding.DecodeString(encoded)
+	}
+	return value, nil
+}
+
+// restoreSQL executa os comandos do dump SQL
+func restoreSQL(tx *gorm.DB, r io.Reader, clean bool) ([]TableCount, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler dump SQL: %w", err)
+	}
+
+	statements, rest := SplitStatements(string(content), tx.Dialector.Name() == "mysql")
+	if strings.TrimSpace(stripComments(rest)) != "" {
+		statements = append(statements, rest)
+	}
+
+	var tables []string
+	for _, statement := range statements {
+		if match := insertTable.FindStringSubmatch(strings.TrimSpace(stripComments(statement))); match != nil {
+			tables = appendTable(tables, match[1])
+		}
+	}
+	if err := checkTables(tx, tables); err != nil {
+		return nil, err
+	}
+	if clean {
+		if err := cleanTables(tx, tables); err != nil {
+			return nil, err
+		}
+	}
+
+	counts := make([]TableCount, len(tables))
+	for i, table := range tables {
+		counts[i].Table = table
+	}
+	for _, statement := range statements {
+		result := tx.Exec(statement)
+		if result.Error != nil {
+			return nil, fmt.Errorf("erro ao executar %q: %w", summarize(statement), result.Error)
+		}
+		if match := insertTable.FindStringSubmatch(strings.TrimSpace(stripComments(statement))); match != nil {
+			counts[slices.Index(tables, match[1])].Rows += int(result.RowsAffected)
+		}
+	}
+	return counts, nil
+}
+
+// checkTables garante que as tabelas do dump existem no banco
+func checkTables(tx *gorm.DB, tables []string) error {
+	existing, err := Tables(tx)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, table := range tables {
+		if !slices.Contains(existing, table) {
+			missing = append(missing, table)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("tabelas inexistentes no banco: %s (execute gaver migrate up antes do restore)", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// cleanTables apaga os registros das tabelas, das filhas para as referenciadas
+func cleanTables(tx *gorm.DB, tables []string) error {
+	ordered := sortByDependencies(tx, tables)
+	slices.Reverse(ordered)
+
+	for _, table := range ordered {
+		if err := tx.Exec("DELETE FROM ?", clause.Table{Name: table}).Error; err != nil {
+			return fmt.Errorf("erro ao limpar %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// resetSequences ajusta as sequences do PostgreSQL ao maior ID restaurado,
+// para que os próximos inserts não repitam chaves (SQLite e MySQL ajustam
+// o autoincremento sozinhos)
+func resetSequences(tx *gorm.DB, tables []string) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	for _, table := range tables {
+		columns, err := tx.Migrator().ColumnTypes(table)
+		if err != nil {
+			return fmt.Errorf("erro ao ler colunas de %s: %w", table, err)
+		}
+
+		for _, column := range columns {
+			var sequence *string
+			if err := tx.Raw("SELECT pg_get_serial_sequence(?, ?)", table, column.Name()).Scan(&sequence).Error; err != nil {
+				return fmt.Errorf("erro ao buscar sequence de %s.%s: %w", table, column.Name(), err)
+			}
+			if sequence == nil || *sequence == "" {
+				continue
+			}
+
+			err := tx.Exec("SELECT setval(?, COALESCE((SELECT MAX(?) FROM ?), 0) + 1, false)",
+				*sequence, clause.Column{Name: column.Name()}, clause.Table{Name: table}).Error
+			if err != nil {
+				return fmt.Errorf("erro ao ajustar sequence %s: %w", *sequence, err)
+			}
+		}
+	}
+	return nil
+}
+
+func appendTable(tables []string, table string) []string {
+	if slices.Contains(tables, table) {
+		return tables
+	}
+	return append(tables, table)
+}
+
+// summarize encurta o comando para mensagens de erro
+func summarize(statement string) string {
+	statement = strings.Join(strings.Fields(stripComments(statement)), " ")
+	if len(statement) > 80 {
+		return statement[:77] + "..."
+	}
+	return statement
+}
@@ -0,0 +1,248 @@
+package database
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// queryKeywords iniciam comandos que retornam registros
+var queryKeywords = []string{"SELECT", "WITH", "PRAGMA", "SHOW", "EXPLAIN", "DESCRIBE", "DESC", "VALUES", "TABLE"}
+
+const shellHelp = `Comandos terminam com ";" e podem ocupar várias linhas.
+
+  \dt, .tables          lista as tabelas
+  \d <tabela>, .schema  lista as colunas da tabela
+  \?, .help             mostra esta ajuda
+  \q, .quit, exit       sai do shell
+`
+
+// Shell é um prompt SQL interativo sobre a conexão do projeto
+type Shell struct {
+	DB     *gorm.DB
+	In     io.Reader
+	Out    io.Writer
+	Prompt bool // exibe o prompt (entrada em um terminal)
+}
+
+// Run lê e executa comandos até o fim da entrada ou \q. Erros dos comandos
+// são exibidos sem encerrar o shell.
+func (s *Shell) Run() error {
+	backslash := s.DB.Dialector.Name() == "mysql"
+	scanner := bufio.NewScanner(s.In)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var buffer strings.Builder
+	for {
+		if s.Prompt {
+			if buffer.Len() == 0 {
+				fmt.Fprint(s.Out, "gaver> ")
+			} else {
+				fmt.Fprint(s.Out, "   ... ")
+			}
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := scanner.Text()
+
+		// Meta-comandos só no início de um comando
+		if buffer.Len() == 0 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+			if quit, ok := s.meta(trimmed); ok {
+				if quit {
+					return nil
+				}
+				continue
+			}
+		}
+
+		buffer.WriteString(line)
+		buffer.WriteString("\n")
+
+		statements, rest := SplitStatements(buffer.String(), backslash)
+		for _, statement := range statements {
+			if err := Execute(s.DB, statement, s.Out); err != nil {
+				fmt.Fprintf(s.Out, "Erro: %v\n", err)
+			}
+		}
+		buffer.Reset()
+		if strings.TrimSpace(rest) != "" {
+			buffer.WriteString(rest)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("erro ao ler entrada: %w", err)
+	}
+
+	// Último comando sem ";" no fim da entrada
+	if statement := strings.TrimSpace(buffer.String()); strings.TrimSpace(stripComments(statement)) != "" {
+		if err := Execute(s.DB, statement, s.Out); err != nil {
+			fmt.Fprintf(s.Out, "Erro: %v\n", err)
+		}
+	}
+	if s.Prompt {
+		fmt.Fprintln(s.Out)
+	}
+	return nil
+}
+
+// meta executa um meta-comando. ok é false se a linha não for um deles.
+func (s *Shell) meta(line string) (quit bool, ok bool) {
+	fields := strings.Fields(strings.TrimSuffix(line, ";"))
+	switch strings.ToLower(fields[0]) {
+	case `\q`, ".quit", ".exit", "exit", "quit":
+		return true, true
+
+	case `\?`, ".help", "help":
+		fmt.Fprint(s.Out, shellHelp)
+
+	case `\dt`, ".tables":
+		tables, err := Tables(s.DB)
+		if err != nil {
+			fmt.Fprintf(s.Out, "Erro: %v\n", err)
+			return false, true
+		}
+		for _, table := range tables {
+			fmt.Fprintln(s.Out, table)
+		}
+
+	case `\d`, ".schema":
+		if len(fields) < 2 {
+			fmt.Fprintf(s.Out, "Uso: %s <tabela>\n", fields[0])
+			return false, true
+		}
+		if err := describeTable(s.DB, fields[1], s.Out); err != nil {
+			fmt.Fprintf(s.Out, "Erro: %v\n", err)
+		}
+
+	default:
+		return false, false
+	}
+	return false, true
+}
+
+// Execute executa um comando SQL: consultas são exibidas em tabela e os
+// demais comandos informam as linhas afetadas
+func Execute(db *gorm.DB, statement string, out io.Writer) error {
+	if !isQuery(statement) {
+		result := db.Exec(statement)
+		if result.Error != nil {
+			return result.Error
+		}
+		fmt.Fprintf(out, "%d linha(s) afetada(s)\n", result.RowsAffected)
+		return nil
+	}
+
+	rows, err := db.Raw(statement).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	var lines [][]string
+	for rows.Next() {
+		values, err := scanRow(rows, len(columns))
+		if err != nil {
+			return err
+		}
+		line := make([]string, len(values))
+		for i, value := range values {
+			line[i] = displayValue(value)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	printTable(out, columns, lines)
+	fmt.Fprintf(out, "(%d linha(s))\n", len(lines))
+	return nil
+}
+
+// describeTable lista as colunas da tabela
+func describeTable(db *gorm.DB, table string, out io.Writer) error {
+	if !db.Migrator().HasTable(table) {
+		return fmt.Errorf("tabela %s não existe", table)
+	}
+	columns, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return fmt.Errorf("erro ao ler colunas de %s: %w", table, err)
+	}
+
+	var lines [][]string
+	for _, column := range columns {
+		nullable := ""
+		if value, ok := column.Nullable(); ok && !value {
+			nullable = "NOT NULL"
+		}
+		key := ""
+		if value, ok := column.PrimaryKey(); ok && value {
+			key = "PK"
+		}
+		lines = append(lines, []string{column.Name(), column.DatabaseTypeName(), nullable, key})
+	}
+	printTable(out, []string{"coluna", "tipo", "nulo", "chave"}, lines)
+	return nil
+}
+
+// isQuery verifica se o comando retorna registros
+func isQuery(statement string) bool {
+	fields := strings.Fields(stripComments(statement))
+	if len(fields) == 0 {
+		return false
+	}
+	first := strings.ToUpper(strings.TrimLeft(fields[0], "("))
+	for _, keyword := range queryKeywords {
+		if first == keyword {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToUpper(statement), "RETURNING")
+}
+
+func printTable(out io.Writer, columns []string, lines [][]string) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+
+	separators := make([]string, len(columns))
+	for i, column := range columns {
+		separators[i] = strings.Repeat("-", max(len(column), 3))
+	}
+	fmt.Fprintln(w, strings.Join(separators, "\t"))
+
+	for _, line := range lines {
+		fmt.Fprintln(w, strings.Join(line, "\t"))
+	}
+	w.Flush()
+}
+
+// displayValue formata o valor em uma linha da tabela
+func displayValue(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		text = string(v)
+	case time.Time:
+		text = v.Format("2006-01-02 15:04:05")
+	default:
+		text = fmt.Sprint(v)
+	}
+	return strings.NewReplacer("\n", `\n`, "\t", `\t`, "\r", `\r`).Replace(text)
+}
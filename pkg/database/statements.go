@@ -0,0 +1,88 @@
+package database
+
+import "strings"
+
+// sqlState indica onde está cada byte do SQL
+type sqlState int
+
+const (
+	inCode    sqlState = iota
+	inQuote            // string ou identificador entre aspas
+	inComment          // -- ou /* */
+)
+
+// SplitStatements separa os comandos terminados em ";" fora de strings,
+// identificadores entre aspas e comentários. rest é o texto após o último
+// ";" (comando incompleto). backslash trata \ como escape dentro de strings
+// (MySQL).
+func SplitStatements(sql string, backslash bool) (statements []string, rest string) {
+	start := 0
+	scanSQL(sql, backslash, func(i int, state sqlState) {
+		if state == inCode && sql[i] == ';' {
+			statement := strings.TrimSpace(sql[start:i])
+			if strings.TrimSpace(stripComments(statement)) != "" {
+				statements = append(statements, statement)
+			}
+			start = i + 1
+		}
+	})
+	return statements, sql[start:]
+}
+
+// stripComments remove os comentários -- e /* */ fora de strings
+func stripComments(sql string) string {
+	var result strings.Builder
+	scanSQL(sql, false, func(i int, state sqlState) {
+		if state != inComment {
+			result.WriteByte(sql[i])
+		}
+	})
+	return result.String()
+}
+
+// scanSQL percorre o SQL chamando visit com o estado de cada byte
+func scanSQL(sql string, backslash bool, visit func(i int, state sqlState)) {
+	var quote byte
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		if quote != 0 {
+			visit(i, inQuote)
+			switch {
+			case backslash && c == '\\' && quote == '\'' && i+1 < len(sql):
+				i++
+				visit(i, inQuote)
+			case c == quote && i+1 < len(sql) && sql[i+1] == quote:
+				i++
+				visit(i, inQuote)
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			visit(i, inQuote)
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			for ; i < len(sql) && sql[i] != '\n'; i++ {
+				visit(i, inComment)
+			}
+			if i < len(sql) {
+				visit(i, inCode)
+			}
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := len(sql)
+			if close := strings.Index(sql[i+2:], "*/"); close >= 0 {
+				end = i + 2 + close + 2
+			}
+			for ; i < end; i++ {
+				visit(i, inComment)
+			}
+			i--
+		default:
+			visit(i, inCode)
+		}
+	}
+}
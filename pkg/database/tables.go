@@ -0,0 +1,141 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MigrationsTable é a tabela de controle das migrations, fora dos dumps: o
+// banco de destino já tem as próprias migrations aplicadas
+const MigrationsTable = "migrations"
+
+// Tables retorna as tabelas do banco em ordem alfabética, sem as internas do
+// SQLite
+func Tables(db *gorm.DB) ([]string, error) {
+	tables, err := db.Migrator().GetTables()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar tabelas: %w", err)
+	}
+
+	tables = slices.DeleteFunc(tables, func(table string) bool {
+		return strings.HasPrefix(table, "sqlite_")
+	})
+	slices.Sort(tables)
+	return tables, nil
+}
+
+// selectTables filtra as tabelas do banco por only (vazio = todas) e exclude,
+// sem a tabela de migrations, na ordem das chaves estrangeiras
+func selectTables(db *gorm.DB, only, exclude []string) ([]string, error) {
+	tables, err := Tables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range only {
+		if !slices.Contains(tables, table) {
+			return nil, fmt.Errorf("tabela %s não existe", table)
+		}
+	}
+
+	tables = slices.DeleteFunc(tables, func(table string) bool {
+		return table == MigrationsTable || slices.Contains(exclude, table) ||
+			(len(only) > 0 && !slices.Contains(only, table))
+	})
+	return sortByDependencies(db, tables), nil
+}
+
+// sortByDependencies ordena as tabelas para que as referenciadas por chave
+// estrangeira venham antes das que as referenciam (ordem de inserção).
+// Ciclos e tabelas sem chaves mantêm a ordem alfabética.
+func sortByDependencies(db *gorm.DB, tables []string) []string {
+	pending := map[string][]string{}
+	for _, table := range tables {
+		for _, parent := range referencedTables(db, table) {
+			if parent != table && slices.Contains(tables, parent) {
+				pending[table] = append(pending[table], parent)
+			}
+		}
+	}
+
+	var sorted []string
+	for len(sorted) < len(tables) {
+		progress := false
+		for _, table := range tables {
+			if slices.Contains(sorted, table) {
+				continue
+			}
+			ready := !slices.ContainsFunc(pending[table], func(parent string) bool {
+				return !slices.Contains(sorted, parent)
+			})
+			if ready {
+				sorted = append(sorted, table)
+				progress = true
+			}
+		}
+
+		// Ciclo: segue com a primeira tabela restante
+		if !progress {
+			for _, table := range tables {
+				if !slices.Contains(sorted, table) {
+					sorted = append(sorted, table)
+					break
+				}
+			}
+		}
+	}
+	return sorted
+}
+
+// referencedTables retorna as tabelas referenciadas pelas chaves
+// estrangeiras da tabela (vazio se o banco não informar)
+func referencedTables(db *gorm.DB, table string) []string {
+	var query string
+	switch db.Dialector.Name() {
+	case "sqlite":
+		query = `SELECT DISTINCT "table" FROM pragma_foreign_key_list(?)`
+	case "postgres":
+		query = `SELECT DISTINCT ccu.table_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.constraint_column_usage ccu
+				ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+			WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = CURRENT_SCHEMA() AND tc.table_name = ?`
+	case "mysql":
+		query = `SELECT DISTINCT REFERENCED_TABLE_NAME
+			FROM information_schema.KEY_COLUMN_USAGE
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL`
+	default:
+		return nil
+	}
+
+	var parents []string
+	if err := db.Raw(query, table).Scan(&parents).Error; err != nil {
+		return nil
+	}
+	return parents
+}
+
+// Wipe remove todas as tabelas do banco, inclusive a de migrations.
+// Retorna as tabelas removidas.
+func Wipe(db *gorm.DB) ([]string, error) {
+	tables, err := Tables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filhas antes das referenciadas
+	tables = sortByDependencies(db, tables)
+	slices.Reverse(tables)
+
+	values := make([]interface{}, len(tables))
+	for i, table := range tables {
+		values[i] = table
+	}
+	if err := db.Migrator().DropTable(values...); err != nil {
+		return nil, fmt.Errorf("erro ao remover tabelas: %w", err)
+	}
+	return tables, nil
+}
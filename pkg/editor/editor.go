@@ -0,0 +1,629 @@
+package editor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// File representa um arquivo Go carregado para edição via AST
+//
+// Cada edição localiza os nós pela AST, aplica a alteração no código-fonte
+// e reparseia o arquivo, mantendo comentários e formatação do usuário.
+type File struct {
+	Path string
+	src  []byte
+	fset *token.FileSet
+	ast  *ast.File
+}
+
+// Open lê e parseia um arquivo Go do disco
+func Open(path string) (*File, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, src)
+}
+
+// Parse parseia um código-fonte Go já carregado
+func Parse(path string, src []byte) (*File, error) {
+	f := &File{Path: path}
+	if err := f.reset(src); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// reset substitui o código-fonte e reconstrói a AST
+func (f *File) reset(src []byte) error {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, f.Path, src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("erro ao parsear %s: %w", f.Path, err)
+	}
+	f.src = src
+	f.fset = fset
+	f.ast = node
+	return nil
+}
+
+// offset converte uma posição da AST em índice no código-fonte
+func (f *File) offset(pos token.Pos) int {
+	return f.fset.Position(pos).Offset
+}
+
+// splice substitui o trecho [start, end) do código-fonte e reparseia
+func (f *File) splice(start, end int, text string) error {
+	var b strings.Builder
+	b.Write(f.src[:start])
+	b.WriteString(text)
+	b.Write(f.src[end:])
+	return f.reset([]byte(b.String()))
+}
+
+// PackageName retorna o nome do pacote do arquivo
+func (f *File) PackageName() string {
+	return f.ast.Name.Name
+}
+
+// Bytes retorna o código-fonte formatado com gofmt
+func (f *File) Bytes() ([]byte, error) {
+	out, err := format.Source(f.src)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao formatar %s: %w", f.Path, err)
+	}
+	return out, nil
+}
+
+// Save formata e grava o arquivo no disco
+func (f *File) Save() error {
+	out, err := f.Bytes()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.Path, out, 0644)
+}
+
+// ============= IMPORTS =============
+
+// findImport retorna a declaração e o spec de um import pelo caminho
+func (f *File) findImport(importPath string) (*ast.GenDecl, *ast.ImportSpec) {
+	for _, decl := range f.ast.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			if path, err := strconv.Unquote(imp.Path.Value); err == nil && path == importPath {
+				return gen, imp
+			}
+		}
+	}
+	return nil, nil
+}
+
+// HasImport verifica se o arquivo importa o caminho informado
+func (f *File) HasImport(importPath string) bool {
+	_, imp := f.findImport(importPath)
+	return imp != nil
+}
+
+// AddImport adiciona um import se ele ainda não existir
+//
+// Funciona com bloco entre parênteses, import de linha única ou arquivo sem
+// imports. Dentro de um bloco, o import entra no grupo dos imports do mesmo
+// tipo (biblioteca padrão, mesmo domínio ou mesmo módulo) ou em um grupo
+// novo; a ordenação dentro do grupo fica a cargo do gofmt. O import "C" do
+// cgo fica sempre na própria declaração, logo abaixo do preâmbulo.
+func (f *File) AddImport(importPath string) error {
+	if f.HasImport(importPath) {
+		return nil
+	}
+
+	spec := strconv.Quote(importPath)
+	group := importGroup(importPath)
+
+	var lastImport *ast.GenDecl
+	for _, decl := range f.ast.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		lastImport = gen
+		if importsC(gen) {
+			continue
+		}
+
+		// import "x": converter para bloco
+		if !gen.Rparen.IsValid() {
+			old := string(f.src[f.offset(gen.Specs[0].Pos()):f.offset(gen.End())])
+			block := "import (\n\t" + old + "\n\t" + spec + "\n)"
+			return f.splice(f.offset(gen.Pos()), f.offset(gen.End()), block)
+		}
+
+		// import ( ... ): depois do último import do mesmo grupo
+		var last *ast.ImportSpec
+		for _, s := range gen.Specs {
+			imp := s.(*ast.ImportSpec)
+			if path, err := strconv.Unquote(imp.Path.Value); err == nil && importGroup(path) == group {
+				last = imp
+			}
+		}
+		if last != nil {
+			_, end := f.lineRange(last.Pos(), last.End())
+			return f.splice(end, end, "\t"+spec+"\n")
+		}
+
+		// Grupo novo: biblioteca padrão no início, demais no final
+		if group == "std" {
+			end := f.offset(gen.Lparen) + 1
+			for end < len(f.src) && f.src[end-1] != '\n' {
+				end++
+			}
+			return f.splice(end, end, "\t"+spec+"\n\n")
+		}
+		rparen := f.offset(gen.Rparen)
+		return f.splice(rparen, rparen, "\n\t"+spec+"\n")
+	}
+
+	// Só o import "C": nova declaração depois dele
+	if lastImport != nil {
+		end := f.offset(lastImport.End())
+		return f.splice(end, end, "\n\nimport "+spec)
+	}
+
+	// Nenhum import: inserir depois da cláusula package
+	end := f.offset(f.ast.Name.End())
+	return f.splice(end, end, "\n\nimport "+spec+"\n")
+}
+
+// importsC verifica se a declaração é o import "C" do cgo
+func importsC(gen *ast.GenDecl) bool {
+	for _, spec := range gen.Specs {
+		if imp := spec.(*ast.ImportSpec); imp.Path.Value == `"C"` {
+			return true
+		}
+	}
+	return false
+}
+
+// importGroup classifica o import: "std" para a biblioteca padrão, senão o
+// primeiro elemento do caminho (github.com, gorm.io, nome do módulo)
+func importGroup(importPath string) string {
+	first, _, _ := strings.Cut(importPath, "/")
+	if !strings.Contains(first, ".") {
+		if pkg, err := build.Default.Import(importPath, "", build.FindOnly); err == nil && pkg.Goroot {
+			return "std"
+		}
+	}
+	return first
+}
+
+// RemoveImport remove um import (não faz nada se ele não existir)
+func (f *File) RemoveImport(importPath string) error {
+	gen, imp := f.findImport(importPath)
+	if imp == nil {
+		return nil
+	}
+
+	// Import único: remover a declaração inteira
+	if len(gen.Specs) == 1 {
+		start, end := f.lineRange(gen.Pos(), gen.End())
+		return f.splice(start, end, "")
+	}
+
+	// Comentários acima do import são preservados: costumam descrever o bloco
+	start, end := f.lineRange(imp.Pos(), imp.End())
+	return f.splice(start, end, "")
+}
+
+// RemoveImportIfUnused remove o import se o pacote não for mais referenciado
+func (f *File) RemoveImportIfUnused(importPath string) error {
+	_, imp := f.findImport(importPath)
+	if imp == nil {
+		return nil
+	}
+
+	name := importPath[strings.LastIndex(importPath, "/")+1:]
+	if imp.Name != nil {
+		name = imp.Name.Name
+	}
+	if name == "_" || name == "." || f.usesIdent(name) {
+		return nil
+	}
+
+	return f.RemoveImport(importPath)
+}
+
+// usesIdent verifica se algum seletor do arquivo usa o identificador como pacote
+func (f *File) usesIdent(name string) bool {
+	used := false
+	ast.Inspect(f.ast, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return !used
+		}
+		if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == name {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
+
+// ============= FUNÇÕES =============
+
+// FindFunc retorna a função (ou método, se recv não for vazio) pelo nome
+func (f *File) FindFunc(recv, name string) *ast.FuncDecl {
+	for _, decl := range f.ast.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != name || fn.Body == nil {
+			continue
+		}
+		if recvTypeName(fn) == recv {
+			return fn
+		}
+	}
+	return nil
+}
+
+// funcLabel descreve uma função para mensagens de erro
+func funcLabel(recv, name string) string {
+	if recv == "" {
+		return name
+	}
+	return fmt.Sprintf("(*%s).%s", recv, name)
+}
+
+// recvTypeName retorna o nome do tipo receptor de um método ("" para funções)
+func recvTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// ParamName retorna o nome do parâmetro de índice informado da função
+func (f *File) ParamName(recv, name string, index int) (string, error) {
+	fn := f.FindFunc(recv, name)
+	if fn == nil {
+		return "", fmt.Errorf("função %s não encontrada em %s", funcLabel(recv, name), f.Path)
+	}
+
+	i := 0
+	for _, field := range fn.Type.Params.List {
+		for _, ident := range field.Names {
+			if i == index {
+				return ident.Name, nil
+			}
+			i++
+		}
+	}
+	return "", fmt.Errorf("função %s não tem parâmetro %d", funcLabel(recv, name), index)
+}
+
+// HasStmt verifica se algum statement do corpo da função é aceito por match
+func (f *File) HasStmt(recv, name string, match func(stmt ast.Stmt) bool) (bool, error) {
+	fn := f.FindFunc(recv, name)
+	if fn == nil {
+		return false, fmt.Errorf("função %s não encontrada em %s", funcLabel(recv, name), f.Path)
+	}
+
+	for _, stmt := range fn.Body.List {
+		if match(stmt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// AppendStmts adiciona código ao final do corpo da função, separado por uma
+// linha em branco do conteúdo existente
+func (f *File) AppendStmts(recv, name, code string) error {
+	fn := f.FindFunc(recv, name)
+	if fn == nil {
+		return fmt.Errorf("função %s não encontrada em %s", funcLabel(recv, name), f.Path)
+	}
+
+	lbrace := f.offset(fn.Body.Lbrace) + 1
+	rbrace := f.offset(fn.Body.Rbrace)
+
+	// Recuar até o fim do último conteúdo do corpo
+	end := rbrace
+	for end > lbrace && isSpace(f.src[end-1]) {
+		end--
+	}
+
+	text := "\n" + strings.TrimRight(code, "\n") + "\n"
+	if end > lbrace {
+		text = "\n" + text
+	}
+	return f.splice(end, rbrace, text)
+}
+
+// AppendDecl adiciona uma declaração (função, tipo, etc) ao final do arquivo
+func (f *File) AppendDecl(code string) error {
+	end := len(f.src)
+	for end > 0 && isSpace(f.src[end-1]) {
+		end--
+	}
+	return f.splice(end, len(f.src), "\n\n"+strings.TrimSpace(code)+"\n")
+}
+
+// FuncSource retorna o código da função, incluindo o comentário de documentação
+func (f *File) FuncSource(recv, name string) (string, error) {
+	fn := f.FindFunc(recv, name)
+	if fn == nil {
+		return "", fmt.Errorf("função %s não encontrada em %s", funcLabel(recv, name), f.Path)
+	}
+	return string(f.src[f.offset(funcStart(fn)):f.offset(fn.End())]), nil
+}
+
+// ReplaceFunc substitui a função (com o comentário de documentação) pelo código
+// informado. Se a função não existe, o código é adicionado ao final do arquivo.
+func (f *File) ReplaceFunc(recv, name, code string) error {
+	fn := f.FindFunc(recv, name)
+	if fn == nil {
+		return f.AppendDecl(code)
+	}
+	return f.splice(f.offset(funcStart(fn)), f.offset(fn.End()), strings.TrimSpace(code))
+}
+
+// funcStart é o início da função, contando o comentário de documentação
+func funcStart(fn *ast.FuncDecl) token.Pos {
+	if fn.Doc != nil {
+		return fn.Doc.Pos()
+	}
+	return fn.Pos()
+}
+
+// RemoveStmts remove do corpo da função os statements aceitos por match,
+// junto com os comentários que os precedem diretamente
+func (f *File) RemoveStmts(recv, name string, match func(stmt ast.Stmt) bool) (int, error) {
+	fn := f.FindFunc(recv, name)
+	if fn == nil {
+		return 0, fmt.Errorf("função %s não encontrada em %s", funcLabel(recv, name), f.Path)
+	}
+
+	// Coletar intervalos (mesclando os que se sobrepõem)
+	var ranges [][2]int
+	removed := 0
+	for _, stmt := range fn.Body.List {
+		if !match(stmt) {
+			continue
+		}
+		removed++
+		start, end := f.stmtRange(stmt)
+		if n := len(ranges); n > 0 && start <= ranges[n-1][1] {
+			ranges[n-1][1] = max(ranges[n-1][1], end)
+			continue
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+
+	if removed == 0 {
+		return 0, nil
+	}
+
+	// Aplicar de trás para frente para não invalidar offsets
+	for i := len(ranges) - 1; i >= 0; i-- {
+		if err := f.splice(ranges[i][0], ranges[i][1], ""); err != nil {
+			return 0, err
+		}
+	}
+
+	return removed, f.trimBody(recv, name)
+}
+
+// stmtRange retorna o trecho removido com o statement: as linhas inteiras
+// quando ele ocupa linhas próprias, ou só o statement e o ";" que o separa
+// dos vizinhos na mesma linha ({ a(); b() })
+func (f *File) stmtRange(stmt ast.Stmt) (int, int) {
+	startPos := f.nodeStart(stmt)
+	if f.startsLine(startPos) && f.endsLine(stmt.End()) {
+		return f.lineRange(startPos, stmt.End())
+	}
+
+	start, end := f.offset(startPos), f.offset(stmt.End())
+
+	// Separador depois: "a(); " sai junto com a()
+	next := end
+	for next < len(f.src) && isBlank(f.src[next]) {
+		next++
+	}
+	if next < len(f.src) && f.src[next] == ';' {
+		next++
+		for next < len(f.src) && isBlank(f.src[next]) {
+			next++
+		}
+		return start, next
+	}
+
+	// Último da linha: "; b()" sai junto com b()
+	prev := start
+	for prev > 0 && isBlank(f.src[prev-1]) {
+		prev--
+	}
+	if prev > 0 && f.src[prev-1] == ';' {
+		return prev - 1, end
+	}
+	return start, end
+}
+
+// endsLine verifica se depois de pos só há espaços ou um comentário de linha
+func (f *File) endsLine(pos token.Pos) bool {
+	i := f.offset(pos)
+	for i < len(f.src) && isBlank(f.src[i]) {
+		i++
+	}
+	return i == len(f.src) || f.src[i] == '\n' || f.src[i] == '\r' || strings.HasPrefix(string(f.src[i:]), "//")
+}
+
+// nodeStart retorna o início do nó incluindo o comentário colado acima dele
+func (f *File) nodeStart(node ast.Node) token.Pos {
+	start := node.Pos()
+	for {
+		cg := f.commentEndingBefore(start)
+		if cg == nil {
+			return start
+		}
+		start = cg.Pos()
+	}
+}
+
+// commentEndingBefore retorna o grupo de comentários que termina na linha
+// imediatamente anterior a pos (sem linha em branco entre eles)
+func (f *File) commentEndingBefore(pos token.Pos) *ast.CommentGroup {
+	line := f.fset.Position(pos).Line
+	for _, cg := range f.ast.Comments {
+		if f.fset.Position(cg.End()).Line != line-1 || cg.End() >= pos {
+			continue
+		}
+		// Ignorar comentários ao final de outra linha de código
+		if f.startsLine(cg.Pos()) {
+			return cg
+		}
+	}
+	return nil
+}
+
+// startsLine verifica se só há espaços antes de pos na mesma linha
+func (f *File) startsLine(pos token.Pos) bool {
+	for i := f.offset(pos); i > 0 && f.src[i-1] != '\n'; i-- {
+		if !isBlank(f.src[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// lineRange expande [start, end) para as linhas completas do nó
+func (f *File) lineRange(startPos, endPos token.Pos) (int, int) {
+	start := f.offset(startPos)
+	end := f.offset(endPos)
+
+	// Há código antes na mesma linha: remover só o nó
+	if !f.startsLine(startPos) {
+		return start, end
+	}
+	for start > 0 && f.src[start-1] != '\n' {
+		start--
+	}
+
+	for end < len(f.src) && f.src[end] != '\n' {
+		end++
+	}
+	if end < len(f.src) {
+		end++
+	}
+
+	return start, end
+}
+
+// trimBody remove linhas em branco que sobraram no início e no fim do corpo
+func (f *File) trimBody(recv, name string) error {
+	fn := f.FindFunc(recv, name)
+	if fn == nil {
+		return nil
+	}
+
+	// Fim do corpo primeiro, para não invalidar o offset do início
+	rbrace := f.offset(fn.Body.Rbrace)
+	end := rbrace
+	for end > 0 && isSpace(f.src[end-1]) {
+		end--
+	}
+	if strings.Count(string(f.src[end:rbrace]), "\n") > 1 {
+		if err := f.splice(end, rbrace, "\n"); err != nil {
+			return err
+		}
+		fn = f.FindFunc(recv, name)
+	}
+
+	lbrace := f.offset(fn.Body.Lbrace) + 1
+	start := lbrace
+	for start < len(f.src) && isSpace(f.src[start]) {
+		start++
+	}
+	if strings.Count(string(f.src[lbrace:start]), "\n") > 1 {
+		return f.splice(lbrace, start, "\n")
+	}
+	return nil
+}
+
+func isSpace(c byte) bool {
+	return isBlank(c) || c == '\n' || c == '\r'
+}
+
+// isBlank verifica se o caractere é espaço ou tab (sem quebra de linha)
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// ============= HELPERS PARA MATCH =============
+
+// CallsMethod verifica se o statement é uma chamada recv.method(...)
+// cujo primeiro argumento é a string literal informada (recv e firstArg
+// vazios aceitam qualquer valor)
+func CallsMethod(stmt ast.Stmt, recv, method, firstArg string) bool {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != method {
+		return false
+	}
+	if ident, ok := sel.X.(*ast.Ident); !ok || (recv != "" && ident.Name != recv) {
+		return false
+	}
+	if firstArg == "" {
+		return true
+	}
+	if len(call.Args) == 0 {
+		return false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return err == nil && value == firstArg
+}
+
+// ReferencesAny verifica se o statement declara ou usa algum dos identificadores
+func ReferencesAny(stmt ast.Stmt, names ...string) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return !found
+		}
+		for _, name := range names {
+			if ident.Name == name {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
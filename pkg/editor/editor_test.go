@@ -0,0 +1,686 @@
+package editor
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// parse carrega o código de teste, falhando o teste em caso de erro
+func parse(t *testing.T, src string) *File {
+	t.Helper()
+	f, err := Parse("test.go", []byte(src))
+	if err != nil {
+		t.Fatalf("erro ao parsear: %v", err)
+	}
+	return f
+}
+
+// output retorna o código formatado do arquivo
+func output(t *testing.T, f *File) string {
+	t.Helper()
+	out, err := f.Bytes()
+	if err != nil {
+		t.Fatalf("erro ao formatar: %v", err)
+	}
+	return string(out)
+}
+
+// check compara o resultado com o esperado, normalizando o esperado com gofmt
+func check(t *testing.T, f *File, want string) {
+	t.Helper()
+	if got, want := output(t, f), output(t, parse(t, want)); got != want {
+		t.Errorf("resultado:\n%s\nesperado:\n%s", got, want)
+	}
+}
+
+// calls aceita statements recv.method(...)
+func calls(recv, method string) func(ast.Stmt) bool {
+	return func(stmt ast.Stmt) bool {
+		return CallsMethod(stmt, recv, method, "")
+	}
+}
+
+func TestOpenSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(path, []byte("package main\nfunc main() {\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("erro ao abrir: %v", err)
+	}
+	if err := f.AddImport("fmt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("erro ao salvar: %v", err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "package main\n\nimport \"fmt\"\n\nfunc main() {\n}\n"; string(saved) != want {
+		t.Errorf("arquivo salvo:\n%s\nesperado:\n%s", saved, want)
+	}
+
+	if _, err := Open(filepath.Join(t.TempDir(), "nao_existe.go")); err == nil {
+		t.Error("Open deveria falhar com arquivo inexistente")
+	}
+	if _, err := Parse("invalido.go", []byte("package main\nfunc {")); err == nil {
+		t.Error("Parse deveria falhar com código inválido")
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"package main\n", "main"},
+		{"// Package users gerencia usuários\npackage users\n", "users"},
+	}
+
+	for _, tt := range tests {
+		if got := parse(t, tt.src).PackageName(); got != tt.want {
+			t.Errorf("PackageName() = %q, esperado %q", got, tt.want)
+		}
+	}
+}
+
+func TestHasImport(t *testing.T) {
+	src := `package main
+
+import (
+	"fmt"
+	modules "meuapp/modules"
+)
+`
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"fmt", true},
+		{"meuapp/modules", true},
+		{"os", false},
+		{"meuapp", false},
+	}
+
+	f := parse(t, src)
+	for _, tt := range tests {
+		if got := f.HasImport(tt.path); got != tt.want {
+			t.Errorf("HasImport(%q) = %v, esperado %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAddImport(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		path string
+		want string
+	}{
+		{
+			name: "sem imports",
+			src:  "package main\n\nfunc main() {}\n",
+			path: "fmt",
+			want: "package main\n\nimport \"fmt\"\n\nfunc main() {}\n",
+		},
+		{
+			name: "import de linha única",
+			src:  "package main\n\nimport \"fmt\"\n",
+			path: "os",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n",
+		},
+		{
+			name: "bloco no grupo existente",
+			src:  "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/gin-gonic/gin\"\n)\n",
+			path: "github.com/google/uuid",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/gin-gonic/gin\"\n\t\"github.com/google/uuid\"\n)\n",
+		},
+		{
+			name: "grupo novo da biblioteca padrão no início",
+			src:  "package main\n\nimport (\n\t\"github.com/gin-gonic/gin\"\n)\n",
+			path: "os",
+			want: "package main\n\nimport (\n\t\"os\"\n\n\t\"github.com/gin-gonic/gin\"\n)\n",
+		},
+		{
+			name: "grupo novo de terceiros no final",
+			src:  "package main\n\nimport (\n\t\"fmt\"\n)\n",
+			path: "gorm.io/gorm",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\n\t\"gorm.io/gorm\"\n)\n",
+		},
+		{
+			name: "import já existente",
+			src:  "package main\n\nimport (\n\t\"fmt\"\n)\n",
+			path: "fmt",
+			want: "package main\n\nimport (\n\t\"fmt\"\n)\n",
+		},
+		{
+			name: "cgo sem outros imports",
+			src:  "package main\n\n// #include <stdio.h>\nimport \"C\"\n",
+			path: "fmt",
+			want: "package main\n\n// #include <stdio.h>\nimport \"C\"\n\nimport \"fmt\"\n",
+		},
+		{
+			name: "cgo com bloco",
+			src:  "package main\n\n// #include <stdio.h>\nimport \"C\"\n\nimport (\n\t\"fmt\"\n)\n",
+			path: "os",
+			want: "package main\n\n// #include <stdio.h>\nimport \"C\"\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n",
+		},
+		{
+			name: "cgo depois de import de linha única",
+			src:  "package main\n\nimport \"fmt\"\n\n// #include <stdio.h>\nimport \"C\"\n",
+			path: "os",
+			want: "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\n// #include <stdio.h>\nimport \"C\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, tt.src)
+			if err := f.AddImport(tt.path); err != nil {
+				t.Fatalf("erro ao adicionar import: %v", err)
+			}
+			if got := output(t, f); got != tt.want {
+				t.Errorf("resultado:\n%s\nesperado:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveImport(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		path string
+		want string
+	}{
+		{
+			name: "import único",
+			src:  "package main\n\nimport \"fmt\"\n\nfunc main() {}\n",
+			path: "fmt",
+			want: "package main\n\nfunc main() {}\n",
+		},
+		{
+			name: "import em bloco",
+			src:  "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n",
+			path: "os",
+			want: "package main\n\nimport (\n\t\"fmt\"\n)\n",
+		},
+		{
+			name: "preserva comentário do bloco",
+			src:  "package main\n\nimport (\n\t// Módulos\n\t\"meuapp/modules/users\"\n\t\"meuapp/modules/products\"\n)\n",
+			path: "meuapp/modules/users",
+			want: "package main\n\nimport (\n\t// Módulos\n\t\"meuapp/modules/products\"\n)\n",
+		},
+		{
+			name: "import inexistente",
+			src:  "package main\n\nimport \"fmt\"\n",
+			path: "os",
+			want: "package main\n\nimport \"fmt\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, tt.src)
+			if err := f.RemoveImport(tt.path); err != nil {
+				t.Fatalf("erro ao remover import: %v", err)
+			}
+			if got := output(t, f); got != tt.want {
+				t.Errorf("resultado:\n%s\nesperado:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveImportIfUnused(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		path    string
+		removed bool
+	}{
+		{
+			name:    "pacote usado",
+			src:     "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println() }\n",
+			path:    "fmt",
+			removed: false,
+		},
+		{
+			name:    "pacote sem uso",
+			src:     "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() { fmt.Println() }\n",
+			path:    "os",
+			removed: true,
+		},
+		{
+			name:    "alias usado",
+			src:     "package main\n\nimport (\n\tusersModule \"meuapp/modules/users\"\n)\n\nvar m = usersModule.New()\n",
+			path:    "meuapp/modules/users",
+			removed: false,
+		},
+		{
+			name:    "alias sem uso",
+			src:     "package main\n\nimport (\n\tusersModule \"meuapp/modules/users\"\n)\n\nvar users = 1\n",
+			path:    "meuapp/modules/users",
+			removed: true,
+		},
+		{
+			name:    "import em branco",
+			src:     "package main\n\nimport _ \"embed\"\n",
+			path:    "embed",
+			removed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, tt.src)
+			if err := f.RemoveImportIfUnused(tt.path); err != nil {
+				t.Fatalf("erro ao remover import: %v", err)
+			}
+			if got := !f.HasImport(tt.path); got != tt.removed {
+				t.Errorf("removido = %v, esperado %v", got, tt.removed)
+			}
+		})
+	}
+}
+
+const funcsSrc = `package main
+
+type Server struct{}
+
+// Start inicia o servidor
+func (s *Server) Start(addr string, port int) {
+	s.listen(addr)
+}
+
+func (s Server) Name() string { return "server" }
+
+func setup(router *Router, db *DB) {
+	router.Register("users", users.New())
+}
+`
+
+func TestFindFunc(t *testing.T) {
+	tests := []struct {
+		recv  string
+		name  string
+		found bool
+	}{
+		{"Server", "Start", true},
+		{"Server", "Name", true},
+		{"", "setup", true},
+		{"", "Start", false},
+		{"Client", "Start", false},
+		{"", "main", false},
+	}
+
+	f := parse(t, funcsSrc)
+	for _, tt := range tests {
+		if got := f.FindFunc(tt.recv, tt.name) != nil; got != tt.found {
+			t.Errorf("FindFunc(%q, %q) encontrada = %v, esperado %v", tt.recv, tt.name, got, tt.found)
+		}
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := []struct {
+		recv    string
+		name    string
+		index   int
+		want    string
+		wantErr bool
+	}{
+		{"Server", "Start", 0, "addr", false},
+		{"Server", "Start", 1, "port", false},
+		{"", "setup", 0, "router", false},
+		{"", "setup", 1, "db", false},
+		{"", "setup", 2, "", true},
+		{"", "main", 0, "", true},
+	}
+
+	f := parse(t, funcsSrc)
+	for _, tt := range tests {
+		got, err := f.ParamName(tt.recv, tt.name, tt.index)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParamName(%q, %q, %d) erro = %v, esperado erro %v", tt.recv, tt.name, tt.index, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParamName(%q, %q, %d) = %q, esperado %q", tt.recv, tt.name, tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestHasStmt(t *testing.T) {
+	tests := []struct {
+		recv    string
+		name    string
+		match   func(ast.Stmt) bool
+		want    bool
+		wantErr bool
+	}{
+		{"", "setup", calls("router", "Register"), true, false},
+		{"", "setup", calls("router", "Use"), false, false},
+		{"Server", "Start", calls("s", "listen"), true, false},
+		{"", "main", calls("router", "Register"), false, true},
+	}
+
+	f := parse(t, funcsSrc)
+	for _, tt := range tests {
+		got, err := f.HasStmt(tt.recv, tt.name, tt.match)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("HasStmt(%q, %q) erro = %v, esperado erro %v", tt.recv, tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("HasStmt(%q, %q) = %v, esperado %v", tt.recv, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAppendStmts(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		code string
+		want string
+	}{
+		{
+			name: "corpo vazio",
+			src:  "package main\n\nfunc setup() {\n}\n",
+			code: "register()",
+			want: "package main\n\nfunc setup() {\n\tregister()\n}\n",
+		},
+		{
+			name: "corpo vazio em uma linha",
+			src:  "package main\n\nfunc setup() {}\n",
+			code: "register()",
+			want: "package main\n\nfunc setup() {\n\tregister()\n}\n",
+		},
+		{
+			name: "preserva comentários",
+			src:  "package main\n\nfunc setup() {\n\t// Módulos\n\tusers()\n\t// fim\n}\n",
+			code: "products()",
+			want: "package main\n\nfunc setup() {\n\t// Módulos\n\tusers()\n\t// fim\n\n\tproducts()\n}\n",
+		},
+		{
+			name: "chaves dentro de strings",
+			src:  "package main\n\nfunc setup() {\n\tprint(\"}\")\n\tprint(`{`)\n}\n",
+			code: "register()",
+			want: "package main\n\nfunc setup() {\n\tprint(\"}\")\n\tprint(`{`)\n\n\tregister()\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, tt.src)
+			if err := f.AppendStmts("", "setup", tt.code); err != nil {
+				t.Fatalf("erro ao adicionar statements: %v", err)
+			}
+			check(t, f, tt.want)
+		})
+	}
+
+	if err := parse(t, "package main\n").AppendStmts("", "setup", "register()"); err == nil {
+		t.Error("AppendStmts deveria falhar com função inexistente")
+	}
+}
+
+func TestAppendDecl(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		code string
+		want string
+	}{
+		{
+			name: "arquivo só com package",
+			src:  "package main\n",
+			code: "func helper() {}",
+			want: "package main\n\nfunc helper() {}\n",
+		},
+		{
+			name: "depois da última declaração",
+			src:  "package main\n\nfunc main() {}\n\n\n",
+			code: "\n// helper ajuda\nfunc helper() {}\n\n",
+			want: "package main\n\nfunc main() {}\n\n// helper ajuda\nfunc helper() {}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, tt.src)
+			if err := f.AppendDecl(tt.code); err != nil {
+				t.Fatalf("erro ao adicionar declaração: %v", err)
+			}
+			if got := output(t, f); got != tt.want {
+				t.Errorf("resultado:\n%s\nesperado:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncSource(t *testing.T) {
+	tests := []struct {
+		recv    string
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"Server", "Start", "// Start inicia o servidor\nfunc (s *Server) Start(addr string, port int) {\n\ts.listen(addr)\n}", false},
+		{"Server", "Name", "func (s Server) Name() string { return \"server\" }", false},
+		{"", "main", "", true},
+	}
+
+	f := parse(t, funcsSrc)
+	for _, tt := range tests {
+		got, err := f.FuncSource(tt.recv, tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FuncSource(%q, %q) erro = %v, esperado erro %v", tt.recv, tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FuncSource(%q, %q) =\n%s\nesperado:\n%s", tt.recv, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReplaceFunc(t *testing.T) {
+	src := "package main\n\n// old faz algo\nfunc old() {\n\tprintln(1)\n}\n\nfunc keep() {}\n"
+
+	tests := []struct {
+		name string
+		fn   string
+		code string
+		want string
+	}{
+		{
+			name: "substitui com o comentário",
+			fn:   "old",
+			code: "// old faz outra coisa\nfunc old() {\n\tprintln(2)\n}\n",
+			want: "package main\n\n// old faz outra coisa\nfunc old() {\n\tprintln(2)\n}\n\nfunc keep() {}\n",
+		},
+		{
+			name: "função inexistente vai para o final",
+			fn:   "added",
+			code: "func added() {}",
+			want: src + "\nfunc added() {}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, src)
+			if err := f.ReplaceFunc("", tt.fn, tt.code); err != nil {
+				t.Fatalf("erro ao substituir função: %v", err)
+			}
+			if got := output(t, f); got != tt.want {
+				t.Errorf("resultado:\n%s\nesperado:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveStmts(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		match   func(ast.Stmt) bool
+		removed int
+		want    string
+	}{
+		{
+			name:    "statement com comentário",
+			src:     "package main\n\nfunc setup() {\n\t// Usuários\n\tr.Register(\"users\")\n\n\t// Produtos\n\tr.Register(\"products\")\n}\n",
+			match:   func(stmt ast.Stmt) bool { return CallsMethod(stmt, "r", "Register", "users") },
+			removed: 1,
+			want:    "package main\n\nfunc setup() {\n\t// Produtos\n\tr.Register(\"products\")\n}\n",
+		},
+		{
+			name:    "comentário no fim da linha",
+			src:     "package main\n\nfunc setup() {\n\tr.A() // remover\n\tr.B()\n}\n",
+			match:   calls("r", "A"),
+			removed: 1,
+			want:    "package main\n\nfunc setup() {\n\tr.B()\n}\n",
+		},
+		{
+			name:    "statement de várias linhas",
+			src:     "package main\n\nfunc setup() {\n\tr.Use(func() {\n\t\tprintln(\"}\")\n\t})\n\tr.Run()\n}\n",
+			match:   calls("r", "Use"),
+			removed: 1,
+			want:    "package main\n\nfunc setup() {\n\tr.Run()\n}\n",
+		},
+		{
+			name:    "primeiro de um corpo em uma linha",
+			src:     "package main\n\nfunc setup() { r.A(); r.B() }\n",
+			match:   calls("r", "A"),
+			removed: 1,
+			want:    "package main\n\nfunc setup() { r.B() }\n",
+		},
+		{
+			name:    "último de um corpo em uma linha",
+			src:     "package main\n\nfunc setup() { r.A(); r.B() }\n",
+			match:   calls("r", "B"),
+			removed: 1,
+			want:    "package main\n\nfunc setup() { r.A() }\n",
+		},
+		{
+			name:    "todos de um corpo em uma linha",
+			src:     "package main\n\nfunc setup() { r.A(); r.B() }\n",
+			match:   func(ast.Stmt) bool { return true },
+			removed: 2,
+			want:    "package main\n\nfunc setup() {}\n",
+		},
+		{
+			name:    "único de um corpo em uma linha",
+			src:     "package main\n\nfunc setup() { r.A() }\n",
+			match:   calls("r", "A"),
+			removed: 1,
+			want:    "package main\n\nfunc setup() {}\n",
+		},
+		{
+			name:    "dividindo a linha com outro statement",
+			src:     "package main\n\nfunc setup() {\n\tr.A(); r.B()\n\tr.C()\n}\n",
+			match:   calls("r", "A"),
+			removed: 1,
+			want:    "package main\n\nfunc setup() {\n\tr.B()\n\tr.C()\n}\n",
+		},
+		{
+			name:    "nenhum aceito",
+			src:     "package main\n\nfunc setup() {\n\tr.A()\n}\n",
+			match:   calls("r", "Z"),
+			removed: 0,
+			want:    "package main\n\nfunc setup() {\n\tr.A()\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t, tt.src)
+			removed, err := f.RemoveStmts("", "setup", tt.match)
+			if err != nil {
+				t.Fatalf("erro ao remover statements: %v", err)
+			}
+			if removed != tt.removed {
+				t.Errorf("removidos = %d, esperado %d", removed, tt.removed)
+			}
+			check(t, f, tt.want)
+
+			// Repetir não remove mais nada
+			if again, err := f.RemoveStmts("", "setup", tt.match); err != nil || again != 0 {
+				t.Errorf("segunda remoção = %d, %v; esperado 0", again, err)
+			}
+		})
+	}
+
+	if _, err := parse(t, "package main\n").RemoveStmts("", "setup", calls("r", "A")); err == nil {
+		t.Error("RemoveStmts deveria falhar com função inexistente")
+	}
+}
+
+func TestCallsMethod(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		recv     string
+		method   string
+		firstArg string
+		want     bool
+	}{
+		{`registry.Register("users", m)`, "registry", "Register", "users", true},
+		{`registry.Register("users", m)`, "", "Register", "", true},
+		{`registry.Register("users", m)`, "registry", "Register", "products", false},
+		{`registry.Register("users", m)`, "router", "Register", "users", false},
+		{`registry.Register("users", m)`, "registry", "Use", "", false},
+		{`registry.Register(name, m)`, "registry", "Register", "users", false},
+		{`registry.Register()`, "registry", "Register", "users", false},
+		{`Register("users")`, "", "Register", "users", false},
+		{`x := registry.Register("users")`, "registry", "Register", "users", false},
+	}
+
+	for _, tt := range tests {
+		stmt := parseStmt(t, tt.stmt)
+		if got := CallsMethod(stmt, tt.recv, tt.method, tt.firstArg); got != tt.want {
+			t.Errorf("CallsMethod(%s, %q, %q, %q) = %v, esperado %v", tt.stmt, tt.recv, tt.method, tt.firstArg, got, tt.want)
+		}
+	}
+}
+
+func TestReferencesAny(t *testing.T) {
+	tests := []struct {
+		stmt  string
+		names []string
+		want  bool
+	}{
+		{`usersModule := users.New()`, []string{"usersModule"}, true},
+		{`registry.Register("users", usersModule)`, []string{"usersModule"}, true},
+		{`registry.Register("users", usersModule)`, []string{"productsModule", "usersModule"}, true},
+		{`registry.Register("users", productsModule)`, []string{"usersModule"}, false},
+		{`println("usersModule")`, []string{"usersModule"}, false},
+	}
+
+	for _, tt := range tests {
+		stmt := parseStmt(t, tt.stmt)
+		if got := ReferencesAny(stmt, tt.names...); got != tt.want {
+			t.Errorf("ReferencesAny(%s, %v) = %v, esperado %v", tt.stmt, tt.names, got, tt.want)
+		}
+	}
+}
+
+// parseStmt parseia um único statement dentro de uma função
+func parseStmt(t *testing.T, code string) ast.Stmt {
+	t.Helper()
+	f := parse(t, "package main\n\nfunc f() {\n"+code+"\n}\n")
+	fn := f.FindFunc("", "f")
+	if len(fn.Body.List) != 1 {
+		t.Fatalf("esperado 1 statement em %q", strings.TrimSpace(code))
+	}
+	return fn.Body.List[0]
+}
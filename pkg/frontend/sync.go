@@ -0,0 +1,363 @@
+package frontend
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/generator"
+	"github.com/Dalistor/gaver/pkg/openapi"
+	gaverParser "github.com/Dalistor/gaver/pkg/parser"
+)
+
+var (
+	// SourceDir é o diretório do código do frontend Quasar
+	SourceDir = filepath.Join("frontend", "src")
+
+	// TypesDir recebe as interfaces TypeScript de cada model
+	TypesDir = filepath.Join(SourceDir, "types")
+
+	// ComposablesDir recebe os composables tipados de cada model
+	ComposablesDir = filepath.Join(SourceDir, "composables")
+
+	// ValidatorsDir recebe as regras de validação de formulário de cada model
+	ValidatorsDir = filepath.Join(SourceDir, "validators")
+)
+
+// GeneratedMarker identifica arquivos gerados (e que podem ser sobrescritos ou removidos)
+const GeneratedMarker = "Gerado por gaver frontend sync"
+
+// writeMethods mapeia a action do handler para o método usado nas annotations writable
+var writeMethods = []struct {
+	Action string
+	Method string
+}{
+	{"Create", "POST"},
+	{"Update", "PUT"},
+	{"Patch", "PATCH"},
+}
+
+var pathParamRegex = regexp.MustCompile(`[:*][A-Za-z0-9_]+`)
+
+// Model contém os dados usados nos templates de um model
+type Model struct {
+	Module     string
+	Name       string // nome da interface (pode ser prefixado com o módulo)
+	File       string // nome do arquivo em types/ (sem extensão)
+	Composable string // useProducts
+
+	Imports []Import
+	Read    []Field
+	Writes  []WriteType
+	Filters []Field
+
+	IDType  string // tipo do parâmetro id nas rotas
+	IDField string // propriedade usada para atualizar a lista local ("" se não legível)
+
+	// Expressões TypeScript com o path de cada rota ("" se a rota não existe)
+	ListPath   string
+	GetPath    string
+	CreatePath string
+	UpdatePath string
+	PatchPath  string
+	DeletePath string
+}
+
+// Import representa um model referenciado por outro
+type Import struct {
+	Name string
+	File string
+}
+
+// WriteType representa a interface de entrada de um método (ProductCreate, ...)
+type WriteType struct {
+	Name   string
+	Action string
+	Fields []Field
+}
+
+// RulesName retorna o nome da constante com as regras de validação (orderItemCreateRules)
+func (w WriteType) RulesName() string {
+	return strings.ToLower(w.Name[:1]) + w.Name[1:] + "Rules"
+}
+
+// RuleHelpers lista os helpers de validators/rules.ts usados pelo model
+func (m *Model) RuleHelpers() string {
+	used := map[string]bool{}
+	for _, w := range m.Writes {
+		for _, field := range w.Fields {
+			for _, rule := range field.Rules {
+				used[rule[:strings.Index(rule, "(")]] = true
+			}
+		}
+	}
+	return strings.Join(sortedKeys(used), ", ")
+}
+
+// HasRoutes indica se o model tem rotas CRUD (e portanto um composable)
+func (m *Model) HasRoutes() bool {
+	return m.ListPath != "" || m.GetPath != "" || m.CreatePath != "" ||
+		m.UpdatePath != "" || m.PatchPath != "" || m.DeletePath != ""
+}
+
+// WriteName retorna o nome da interface de entrada da action ("" se não existe)
+func (m *Model) WriteName(action string) string {
+	for _, w := range m.Writes {
+		if w.Action == action {
+			return w.Name
+		}
+	}
+	return ""
+}
+
+// TypeImports lista os tipos usados pelo composable
+func (m *Model) TypeImports() string {
+	names := []string{m.Name}
+	for _, w := range m.Writes {
+		names = append(names, w.Name)
+	}
+	if m.ListPath != "" {
+		names = append(names, m.Name+"ListParams")
+	}
+	return strings.Join(names, ", ")
+}
+
+// Exists verifica se o projeto tem frontend (projetos web, desktop e mobile)
+func Exists() bool {
+	info, err := os.Stat(SourceDir)
+	return err == nil && info.IsDir()
+}
+
+// Sync gera as interfaces e os composables de todos os models dos módulos e
+// remove os arquivos gerados de models que não existem mais.
+// Retorna os arquivos gravados.
+func Sync(modules []*openapi.Module) ([]string, error) {
+	gen := templates.New(".")
+	keep := map[string]bool{}
+	var written []string
+
+	// Helpers de validação compartilhados pelos validators de cada model
+	rulesFile := filepath.Join(ValidatorsDir, "rules.ts")
+	if err := gen.Generate("frontend_rules.tmpl", rulesFile, nil); err != nil {
+		return written, fmt.Errorf("erro ao gerar %s: %w", rulesFile, err)
+	}
+	keep[rulesFile] = true
+	written = append(written, rulesFile)
+
+	for _, model := range BuildModels(modules) {
+		typesFile := filepath.Join(TypesDir, model.File+".ts")
+		if err := gen.Generate("frontend_types.tmpl", typesFile, model); err != nil {
+			return written, fmt.Errorf("erro ao gerar %s: %w", typesFile, err)
+		}
+		keep[typesFile] = true
+		written = append(written, typesFile)
+
+		if len(model.Writes) > 0 {
+			validatorsFile := filepath.Join(ValidatorsDir, model.File+".ts")
+			if err := gen.Generate("frontend_validators.tmpl", validatorsFile, model); err != nil {
+				return written, fmt.Errorf("erro ao gerar %s: %w", validatorsFile, err)
+			}
+			keep[validatorsFile] = true
+			written = append(written, validatorsFile)
+		}
+
+		if !model.HasRoutes() {
+			continue
+		}
+
+		composableFile := filepath.Join(ComposablesDir, model.Composable+".ts")
+		if err := gen.Generate("frontend_composable.tmpl", composableFile, model); err != nil {
+			return written, fmt.Errorf("erro ao gerar %s: %w", composableFile, err)
+		}
+		keep[composableFile] = true
+		written = append(written, composableFile)
+	}
+
+	if err := removeStale(keep); err != nil {
+		return written, err
+	}
+
+	return written, nil
+}
+
+// BuildModels monta os dados de todos os models dos módulos
+func BuildModels(modules []*openapi.Module) []*Model {
+	names := openapi.SchemaNames(modules)
+
+	var models []*Model
+	for _, module := range modules {
+		for _, name := range sortedKeys(module.Models) {
+			metadata := module.Models[name]
+			model := buildModel(module, metadata, names)
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+func buildModel(module *openapi.Module, metadata *gaverParser.ModelMetadata, names map[string]string) *Model {
+	name := names[module.Name+"."+metadata.Name]
+	resolver := newResolver(module.Name, name, names)
+
+	model := &Model{
+		Module:     module.Name,
+		Name:       name,
+		File:       toKebabCase(name),
+		Composable: "use" + generator.Pluralize(name),
+		IDType:     "string | number",
+	}
+
+	hasID := false
+	for _, field := range metadata.Fields {
+		if field.JSONName() == "" {
+			continue
+		}
+
+		if !hasID && (field.PrimaryKey || field.Name == "ID") {
+			hasID = true
+			model.IDType = resolver.fieldType(field)
+			if field.IsReadable() {
+				model.IDField = field.JSONName()
+			}
+		}
+
+		if field.IsReadable() {
+			model.Read = append(model.Read, Field{
+				Name:     field.JSONName(),
+				Type:     resolver.fieldType(field),
+				Optional: isRelation(field), // relacionamentos só vêm com Preload
+			})
+		}
+
+		if field.IsFilterable() {
+			model.Filters = append(model.Filters, Field{
+				Name:     field.JSONName(),
+				Type:     strings.TrimSuffix(resolver.fieldType(field), " | null"),
+				Optional: true,
+			})
+		}
+	}
+
+	for _, route := range module.Routes {
+		if route.Model != metadata.Name {
+			continue
+		}
+
+		path := pathExpr(route.Path)
+		switch route.Action {
+		case "List":
+			model.ListPath = path
+		case "Get":
+			model.GetPath = path
+		case "Create":
+			model.CreatePath = path
+		case "Update":
+			model.UpdatePath = path
+		case "Patch":
+			model.PatchPath = path
+		case "Delete":
+			model.DeletePath = path
+		}
+	}
+
+	for _, w := range writeMethods {
+		if !model.hasAction(w.Action) {
+			continue
+		}
+
+		write := WriteType{Name: name + w.Action, Action: w.Action}
+		for _, field := range metadata.Fields {
+			if field.PrimaryKey || field.JSONName() == "" || !field.IsScalar() || !field.IsWritableInMethod(w.Method) {
+				continue
+			}
+			write.Fields = append(write.Fields, Field{
+				Name:     field.JSONName(),
+				Type:     resolver.fieldType(field),
+				Optional: !field.Required || w.Method == "PATCH", // PATCH é parcial
+				Rules:    fieldRules(field, w.Method),
+			})
+		}
+		model.Writes = append(model.Writes, write)
+	}
+
+	for _, ref := range resolver.imports() {
+		model.Imports = append(model.Imports, Import{Name: ref, File: toKebabCase(ref)})
+	}
+
+	return model
+}
+
+func (m *Model) hasAction(action string) bool {
+	switch action {
+	case "Create":
+		return m.CreatePath != ""
+	case "Update":
+		return m.UpdatePath != ""
+	case "Patch":
+		return m.PatchPath != ""
+	}
+	return false
+}
+
+// pathExpr converte um path do gin em expressão TypeScript
+// (/products/:id -> `/products/${id}`, /products -> '/products')
+func pathExpr(path string) string {
+	if !pathParamRegex.MatchString(path) {
+		return "'" + path + "'"
+	}
+	return "`" + pathParamRegex.ReplaceAllLiteralString(path, "${id}") + "`"
+}
+
+// removeStale remove arquivos gerados que não fazem mais parte do projeto
+func removeStale(keep map[string]bool) error {
+	for _, dir := range []string{TypesDir, ComposablesDir, ValidatorsDir} {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.ts"))
+		for _, file := range files {
+			if keep[file] || !isGenerated(file) {
+				continue
+			}
+			if err := os.Remove(file); err != nil {
+				return fmt.Errorf("erro ao remover %s: %w", file, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isGenerated verifica se a primeira linha do arquivo tem o marcador do gaver
+func isGenerated(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	return scanner.Scan() && strings.Contains(scanner.Text(), GeneratedMarker)
+}
+
+// toKebabCase converte CamelCase em kebab-case (OrderItem -> order-item)
+func toKebabCase(s string) string {
+	var result strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			result.WriteRune('-')
+		}
+		result.WriteRune(r)
+	}
+	return strings.ToLower(result.String())
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,96 @@
+package frontend
+
+import (
+	"strings"
+
+	gaverParser "github.com/Dalistor/gaver/pkg/parser"
+)
+
+// Field representa uma propriedade de uma interface TypeScript
+type Field struct {
+	Name     string
+	Type     string
+	Optional bool
+	Rules    []string // regras de validação (apenas em interfaces de escrita)
+}
+
+// tsResolver converte tipos Go em tipos TypeScript, resolvendo
+// relacionamentos com outros models do mesmo módulo
+type tsResolver struct {
+	module string
+	self   string
+	names  map[string]string // "modulo.Model" -> nome TS
+
+	// Models referenciados (nome TS), para gerar os imports
+	refs map[string]bool
+}
+
+func newResolver(module, self string, names map[string]string) *tsResolver {
+	return &tsResolver{module: module, self: self, names: names, refs: map[string]bool{}}
+}
+
+// fieldType retorna o tipo TypeScript de um campo do model
+func (r *tsResolver) fieldType(field gaverParser.FieldMetadata) string {
+	if enum := field.EnumValues(); enum != nil {
+		values := make([]string, len(enum))
+		for i, value := range enum {
+			values[i] = jsString(value)
+		}
+		return nullable(strings.Join(values, " | "), field.Type)
+	}
+
+	goType := strings.TrimPrefix(field.Type, "*")
+	if strings.HasPrefix(goType, "[]") && goType != "[]byte" {
+		item := r.goType(strings.TrimPrefix(goType[2:], "*"))
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		return nullable(item+"[]", field.Type)
+	}
+
+	return nullable(r.goType(goType), field.Type)
+}
+
+// goType converte um tipo Go simples em tipo TypeScript
+func (r *tsResolver) goType(goType string) string {
+	switch goType {
+	case "string", "time.Time", "uuid.UUID", "[]byte":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64":
+		return "number"
+	case "gorm.DeletedAt":
+		return "string | null"
+	}
+
+	// Relacionamento com outro model do mesmo módulo
+	if name, ok := r.names[r.module+"."+goType]; ok {
+		if name != r.self {
+			r.refs[name] = true
+		}
+		return name
+	}
+
+	return "Record<string, unknown>"
+}
+
+// imports retorna os models referenciados em ordem alfabética
+func (r *tsResolver) imports() []string {
+	return sortedKeys(r.refs)
+}
+
+func nullable(tsType, goType string) string {
+	if strings.HasPrefix(goType, "*") && !strings.HasSuffix(tsType, "| null") {
+		return tsType + " | null"
+	}
+	return tsType
+}
+
+// isRelation verifica se o campo é um relacionamento (struct ou slice de structs).
+// A annotation relation também aparece na chave estrangeira, então vale o tipo.
+func isRelation(field gaverParser.FieldMetadata) bool {
+	return !field.IsScalar() && strings.TrimPrefix(field.Type, "*") != "[]byte" && field.Type != "gorm.DeletedAt"
+}
@@ -0,0 +1,71 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gaverParser "github.com/Dalistor/gaver/pkg/parser"
+)
+
+// fieldRules traduz as validações do campo em chamadas dos helpers de
+// validators/rules.ts, na mesma ordem em que pkg/validator as aplica no servidor
+func fieldRules(field gaverParser.FieldMetadata, method string) []string {
+	name := jsString(field.JSONName())
+	var rules []string
+
+	// PATCH é parcial: campos obrigatórios podem ficar de fora
+	if field.Required && method != "PATCH" {
+		rules = append(rules, fmt.Sprintf("required(%s)", name))
+	}
+	if _, ok := field.Validations["email"]; ok {
+		rules = append(rules, fmt.Sprintf("email(%s)", name))
+	}
+	if _, ok := field.Validations["url"]; ok {
+		rules = append(rules, fmt.Sprintf("url(%s)", name))
+	}
+	if pattern, ok := field.Validations["pattern"]; ok && pattern != "" {
+		rules = append(rules, fmt.Sprintf("pattern(%s, %s)", name, jsString(pattern)))
+	}
+	if enum := field.EnumValues(); enum != nil {
+		values := make([]string, len(enum))
+		for i, value := range enum {
+			values[i] = jsString(value)
+		}
+		rules = append(rules, fmt.Sprintf("oneOf(%s, [%s])", name, strings.Join(values, ", ")))
+	}
+	if value, ok := numberValidation(field, "min"); ok {
+		rules = append(rules, fmt.Sprintf("min(%s, %s)", name, value))
+	}
+	if value, ok := numberValidation(field, "max"); ok {
+		rules = append(rules, fmt.Sprintf("max(%s, %s)", name, value))
+	}
+	if value, ok := numberValidation(field, "minLength"); ok {
+		rules = append(rules, fmt.Sprintf("minLength(%s, %s)", name, value))
+	}
+	if value, ok := numberValidation(field, "maxLength"); ok {
+		rules = append(rules, fmt.Sprintf("maxLength(%s, %s)", name, value))
+	}
+
+	return rules
+}
+
+// numberValidation retorna o valor numérico da validação (ignora valores inválidos,
+// como o servidor faz)
+func numberValidation(field gaverParser.FieldMetadata, key string) (string, bool) {
+	value, ok := field.Validations[key]
+	if !ok {
+		return "", false
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// jsString converte o valor em literal de string JavaScript
+func jsString(s string) string {
+	data, _ := json.Marshal(s)
+	return "'" + strings.ReplaceAll(strings.Trim(string(data), `"`), "'", `\'`) + "'"
+}
@@ -0,0 +1,370 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Dalistor/gaver/pkg/parser"
+)
+
+// autoTimeFields são as datas preenchidas pelo GORM
+var autoTimeFields = []string{"CreatedAt", "UpdatedAt", "DeletedAt"}
+
+// columnSize lê o tamanho de varchar(N), char(N) ou size:N da tag gorm
+var columnSize = regexp.MustCompile(`(?:char\((\d+)\)|size:(\d+))`)
+
+// defaultTextLength é o tamanho máximo dos textos sem maxLength nem tamanho de coluna
+const defaultTextLength = 40
+
+// RequiredRelations retorna as relações belongsTo que a factory precisa
+// criar: chave required ou sem ponteiro (a coluna não aceita vazio)
+func RequiredRelations(metadata *parser.ModelMetadata) []RequiredRelation {
+	var relations []RequiredRelation
+	seen := map[string]bool{}
+
+	for _, field := range metadata.Fields {
+		if field.Relation == nil || field.Relation.Type != "belongsTo" {
+			continue
+		}
+
+		relation := RequiredRelation{Type: field.Relation.Type, Model: field.Relation.Model}
+		if field.IsScalar() {
+			// Annotation na chave: CompanyID -> Company
+			relation.Field = field
+			if relation.Model == "" {
+				relation.Model = strings.TrimSuffix(field.Name, "ID")
+			}
+		} else {
+			// Annotation no struct: Company Company -> CompanyID
+			key := foreignKeyField(metadata, field)
+			if key == nil {
+				continue
+			}
+			relation.Field = *key
+			if relation.Model == "" {
+				relation.Model = strings.TrimPrefix(baseType(field), "models.")
+			}
+		}
+
+		if seen[relation.Field.Name] || relation.Model == "" {
+			continue
+		}
+		if !relation.Field.Required && strings.HasPrefix(relation.Field.Type, "*") {
+			continue
+		}
+		seen[relation.Field.Name] = true
+		relations = append(relations, relation)
+	}
+
+	return relations
+}
+
+// foreignKeyField localiza a chave de uma relação declarada no struct: a
+// coluna de foreignKey ou o campo <Nome>ID
+func foreignKeyField(metadata *parser.ModelMetadata, field parser.FieldMetadata) *parser.FieldMetadata {
+	for i := range metadata.Fields {
+		candidate := &metadata.Fields[i]
+		if !candidate.IsScalar() {
+			continue
+		}
+		if field.Relation.ForeignKey != "" && candidate.ColumnName() == field.Relation.ForeignKey {
+			return candidate
+		}
+		if field.Relation.ForeignKey == "" && candidate.Name == field.Name+"ID" {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// factoryData monta a definição da factory: um valor válido para cada
+// campo escalar e a criação das relações obrigatórias localizadas em parents
+func factoryData(metadata *parser.ModelMetadata, moduleName string, parents []FactoryParent, data *FactoryData) error {
+	relationFields := map[string]bool{}
+	for _, relation := range RequiredRelations(metadata) {
+		relationFields[relation.Field.Name] = true
+	}
+	for _, field := range metadata.Fields {
+		if field.Relation != nil && field.IsScalar() {
+			relationFields[field.Name] = true
+		}
+	}
+
+	for _, field := range metadata.Fields {
+		if !hasFactoryValue(field) || relationFields[field.Name] {
+			continue
+		}
+		value, err := factoryValue(field)
+		if err != nil {
+			return err
+		}
+		data.Fields = append(data.Fields, value)
+	}
+
+	for _, parent := range parents {
+		if zeroValue(parent.Field) == "uuid.Nil" {
+			data.ExternalImports = appendUnique(data.ExternalImports, "github.com/google/uuid")
+		}
+
+		relation, ok := factoryRelation(parent, moduleName, metadata.Name)
+		if !ok {
+			data.Fields = append(data.Fields, FactoryFieldData{
+				Name: parent.Field.Name,
+				Expr: zeroValue(parent.Field),
+				Note: "TODO: " + parent.Model + " não é criado pela factory; informe a chave com um override",
+			})
+			continue
+		}
+
+		if parent.Module != moduleName {
+			alias := parent.Module + "factories"
+			relation.Factory = alias + "." + parent.Model
+			data.ProjectImports = appendUnique(data.ProjectImports, alias+` "`+data.ProjectName+"/modules/"+parent.Module+`/factories"`)
+		}
+		data.Relations = append(data.Relations, relation)
+	}
+
+	if len(data.Relations) > 0 {
+		data.StdImports = append(data.StdImports, "context", "fmt")
+	}
+	return nil
+}
+
+// hasFactoryValue verifica se a factory gera valor para o campo: chave
+// primária, datas automáticas, colunas ignoradas pelo GORM e relações ficam de fora
+func hasFactoryValue(field parser.FieldMetadata) bool {
+	if isPrimaryKey(field) || !field.IsScalar() || slices.Contains(autoTimeFields, field.Name) {
+		return false
+	}
+	tag := field.GORMTag
+	return tag != "-" && !strings.Contains(tag, "autoCreateTime") && !strings.Contains(tag, "autoUpdateTime")
+}
+
+// factoryPatternValues é quantos textos distintos a factory sorteia para um
+// campo com pattern
+const factoryPatternValues = 10
+
+// factoryValue gera a expressão de um valor válido para o campo
+func factoryValue(field parser.FieldMetadata) (FactoryFieldData, error) {
+	value := FactoryFieldData{Name: field.Name}
+	base := baseType(field)
+
+	switch {
+	case isInteger(field):
+		value.Expr = integerFactoryValue(field)
+
+	case isNumber(field):
+		low, high := numberRange(field)
+		value.Expr = "factory.Float(" + formatNumber(low) + ", " + formatNumber(high) + ")"
+		if base != "float64" {
+			value.Expr = base + "(" + value.Expr + ")"
+		}
+
+	case base == "bool":
+		value.Expr = "factory.Bool()"
+
+	case base == "time.Time":
+		value.Expr = "factory.Time()"
+
+	case base == "uuid.UUID":
+		value.Expr = "factory.UUID()"
+
+	case len(field.EnumValues()) > 0:
+		value.Expr = "factory.Pick(" + quoteAll(field.EnumValues()) + ")"
+
+	case hasValidation(field, "email"):
+		value.Expr = "factory.Email()"
+
+	case hasValidation(field, "url"):
+		value.Expr = "factory.URL()"
+
+	case field.Validations["pattern"] != "":
+		values := patternValues(field, factoryPatternValues)
+		if len(values) == 0 {
+			return value, fmt.Errorf("não foi possível gerar um valor para %s que atenda pattern:%s", field.Name, field.Validations["pattern"])
+		}
+		value.Expr = "factory.Pick(" + quoteAll(values) + ")"
+		if field.Unique && len(values) < factoryPatternValues {
+			value.Note = "unique: há poucos valores possíveis, use overrides para não repetir"
+		}
+
+	case field.Owner:
+		value.Expr = "factory.UUID().String()"
+		value.Note = "Dono do registro: use um override com o ID de um usuário existente"
+
+	default:
+		value.Expr = textFactoryValue(field)
+	}
+
+	if strings.HasPrefix(field.Type, "*") {
+		value.Expr = "factory.Ptr(" + value.Expr + ")"
+	}
+	if field.Unique && (len(field.EnumValues()) > 0 || base == "bool") {
+		value.Note = "unique: há poucos valores possíveis, use overrides para não repetir"
+	}
+	return value, nil
+}
+
+// integerFactoryValue sorteia no intervalo de min/max; campos unique sem
+// max usam a sequência da factory
+func integerFactoryValue(field parser.FieldMetadata) string {
+	low, high := numberRange(field)
+	low, high = math.Ceil(low), math.Floor(high)
+
+	expr := "factory.Int(" + formatNumber(low) + ", " + formatNumber(high) + ")"
+	_, hasMin := numberValidation(field, "min")
+	if _, hasMax := numberValidation(field, "max"); field.Unique && !hasMax {
+		switch {
+		case !hasMin || low == 1:
+			expr = "seq"
+		case low == 0:
+			expr = "seq - 1"
+		default:
+			expr = formatNumber(low-1) + " + seq"
+		}
+	}
+
+	if base := baseType(field); base != "int" {
+		expr = base + "(" + expr + ")"
+	}
+	return expr
+}
+
+// numberRange retorna o intervalo de min/max; sem um dos limites, usa uma
+// faixa de 1000 a partir do outro (ou de 0)
+func numberRange(field parser.FieldMetadata) (float64, float64) {
+	low, hasMin := numberValidation(field, "min")
+	high, hasMax := numberValidation(field, "max")
+
+	switch {
+	case !hasMin && !hasMax:
+		low, high = 0, 1000
+	case !hasMin:
+		low = math.Min(0, high-1000)
+	case !hasMax:
+		high = low + 1000
+	}
+	if limits, ok := integerLimits[baseType(field)]; ok {
+		low, high = math.Max(low, limits[0]), math.Min(high, limits[1])
+	}
+	return low, high
+}
+
+// integerLimits são as faixas dos inteiros pequenos (os demais comportam a
+// faixa padrão)
+var integerLimits = map[string][2]float64{
+	"int8":   {math.MinInt8, math.MaxInt8},
+	"int16":  {math.MinInt16, math.MaxInt16},
+	"uint":   {0, math.MaxUint32},
+	"uint8":  {0, math.MaxUint8},
+	"uint16": {0, math.MaxUint16},
+	"uint32": {0, math.MaxUint32},
+	"uint64": {0, math.MaxUint32},
+}
+
+// textFactoryValue gera um texto com tamanho entre minLength e maxLength
+// (ou o tamanho da coluna); campos unique recebem um sufixo que não se repete
+// e campos como CustomerName, um nome de pessoa
+func textFactoryValue(field parser.FieldMetadata) string {
+	maxLength := defaultTextLength
+	if size, ok := columnLength(field); ok {
+		maxLength = min(size, defaultTextLength)
+	}
+	if value, ok := lengthValidation(field, "maxLength"); ok {
+		maxLength = value
+	}
+
+	minLength := min(3, maxLength)
+	if value, ok := lengthValidation(field, "minLength"); ok {
+		minLength = value
+		maxLength = max(maxLength, minLength)
+	}
+
+	switch {
+	case field.Unique:
+		return "factory.UniqueText(" + strconv.Itoa(minLength) + ", " + strconv.Itoa(maxLength) + ")"
+	case field.Name != "Name" && strings.HasSuffix(field.Name, "Name") && minLength <= 5 && maxLength >= 25:
+		return "factory.Name()"
+	}
+	return "factory.Text(" + strconv.Itoa(minLength) + ", " + strconv.Itoa(maxLength) + ")"
+}
+
+func columnLength(field parser.FieldMetadata) (int, bool) {
+	match := columnSize.FindStringSubmatch(field.GORMTag)
+	if match == nil {
+		return 0, false
+	}
+	size, err := strconv.Atoi(match[1] + match[2])
+	return size, err == nil && size > 0
+}
+
+// factoryRelation monta a criação do model relacionado. Retorna false se a
+// chave não puder ser preenchida com o ID dele (model não encontrado, tipos
+// incompatíveis ou relação com o próprio model, que criaria registros sem fim).
+func factoryRelation(parent FactoryParent, moduleName, modelName string) (FactoryRelationData, bool) {
+	if parent.Module == "" || (parent.Module == moduleName && parent.Model == modelName) {
+		return FactoryRelationData{}, false
+	}
+
+	key := baseType(parent.Field)
+	variable := ToLower(parent.Model)
+	if slices.Contains([]string{"record", "ctx", "err", "factory", "models", "fmt", "context", "uuid"}, variable) {
+		variable = "parent"
+	}
+
+	assign := variable + ".ID"
+	switch {
+	case key == parent.IDType:
+	case key == "string" && parent.IDType == "uuid.UUID":
+		assign += ".String()"
+	case isInteger(parent.Field) && isIntegerType(parent.IDType):
+		assign = key + "(" + assign + ")"
+	default:
+		return FactoryRelationData{}, false
+	}
+	if strings.HasPrefix(parent.Field.Type, "*") {
+		assign = "factory.Ptr(" + assign + ")"
+	}
+
+	return FactoryRelationData{
+		Type:    parent.Type,
+		Model:   parent.Model,
+		Field:   parent.Field.Name,
+		Factory: parent.Model,
+		Var:     variable,
+		Zero:    zeroValue(parent.Field),
+		Assign:  assign,
+	}, true
+}
+
+func isIntegerType(goType string) bool {
+	return strings.HasPrefix(goType, "int") || strings.HasPrefix(goType, "uint")
+}
+
+// zeroValue retorna a expressão do valor zero do campo
+func zeroValue(field parser.FieldMetadata) string {
+	switch {
+	case strings.HasPrefix(field.Type, "*"):
+		return "nil"
+	case isNumber(field):
+		return "0"
+	case field.Type == "string":
+		return `""`
+	case field.Type == "bool":
+		return "false"
+	case field.Type == "uuid.UUID":
+		return "uuid.Nil"
+	}
+	return field.Type + "{}"
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
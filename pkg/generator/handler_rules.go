@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Dalistor/gaver/pkg/parser"
+)
+
+// writeMethods são os métodos HTTP com corpo nas rotas de CRUD
+var writeMethods = []string{"POST", "PUT", "PATCH"}
+
+// CRUDRoute é uma rota do CRUD gerado para o model
+type CRUDRoute struct {
+	Action  string // ação de permissions (list, get, create, update, patch, delete)
+	Method  string
+	Path    string
+	Handler string
+}
+
+// CRUDRoutes retorna as rotas do CRUD em resourcePath, na ordem de registro
+func CRUDRoutes(resourcePath string) []CRUDRoute {
+	return []CRUDRoute{
+		{"list", "GET", resourcePath, "List"},
+		{"get", "GET", resourcePath + "/:id", "Get"},
+		{"create", "POST", resourcePath, "Create"},
+		{"update", "PUT", resourcePath + "/:id", "Update"},
+		{"patch", "PATCH", resourcePath + "/:id", "Patch"},
+		{"delete", "DELETE", resourcePath + "/:id", "Delete"},
+	}
+}
+
+// RouteMiddlewares converte os papéis de uma ação nos middlewares da rota:
+// any (ou sem permissão) é pública, auth e owner exigem apenas login (owner é
+// aplicado no handler) e os demais papéis passam por RequireRole
+func RouteMiddlewares(roles []string) []string {
+	if len(roles) == 0 || slices.Contains(roles, parser.PermissionAny) {
+		return nil
+	}
+	if slices.Contains(roles, parser.PermissionAuth) || slices.Contains(roles, parser.PermissionOwner) {
+		return []string{"middlewares.Auth()"}
+	}
+
+	quoted := make([]string, len(roles))
+	for i, role := range roles {
+		quoted[i] = fmt.Sprintf("%q", role)
+	}
+	return []string{"middlewares.Auth()", "middlewares.RequireRole(" + strings.Join(quoted, ", ") + ")"}
+}
+
+// protectedRoutes monta as rotas do CRUD que exigem login, para o teste de
+// requisições sem token
+func protectedRoutes(metadata *parser.ModelMetadata, resourcePath string, methods map[string]bool) []TestRouteData {
+	var routes []TestRouteData
+	for _, route := range CRUDRoutes(resourcePath) {
+		middlewares := RouteMiddlewares(metadata.PermissionFor(route.Action))
+		if !methods[route.Action] || len(middlewares) == 0 {
+			continue
+		}
+		routes = append(routes, TestRouteData{
+			Method:      route.Method,
+			Path:        route.Path,
+			Request:     strings.Replace(route.Path, ":id", "1", 1),
+			Handler:     route.Handler,
+			Middlewares: strings.Join(middlewares, ", "),
+		})
+	}
+	return routes
+}
+
+// isWritableField verifica se o campo entra no corpo do método (mesmo
+// critério do schema de entrada do OpenAPI e dos tipos do frontend)
+func isWritableField(field parser.FieldMetadata, method string) bool {
+	return !field.PrimaryKey && field.JSONName() != "" && field.IsScalar() && field.IsWritableInMethod(method)
+}
+
+// isWritableInAny verifica se o campo entra no corpo de algum método
+func isWritableInAny(field parser.FieldMetadata) bool {
+	for _, method := range writeMethods {
+		if isWritableField(field, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// writableData monta os campos aceitos em cada método
+func writableData(metadata *parser.ModelMetadata) []WritableMethodData {
+	var data []WritableMethodData
+
+	for _, method := range writeMethods {
+		writable := WritableMethodData{Method: method}
+		for _, field := range metadata.Fields {
+			if isWritableField(field, method) {
+				writable.Fields = append(writable.Fields, FilterFieldData{
+					Param:  field.JSONName(),
+					Column: field.ColumnName(),
+				})
+			}
+		}
+		data = append(data, writable)
+	}
+
+	return data
+}
+
+// hiddenFields retorna os campos do model que não vão na resposta: os sem
+// readable, exceto a chave primária, que o cliente usa para endereçar o registro
+func hiddenFields(metadata *parser.ModelMetadata) []string {
+	var hidden []string
+	for _, field := range metadata.Fields {
+		if field.JSONName() == "" || field.IsReadable() || isPrimaryKey(field) {
+			continue
+		}
+		hidden = append(hidden, field.Name)
+	}
+	return hidden
+}
+
+func isPrimaryKey(field parser.FieldMetadata) bool {
+	return field.PrimaryKey || field.Name == "ID"
+}
+
+// validationRules converte as annotations dos campos graváveis em literais
+// validation.Rule
+func validationRules(metadata *parser.ModelMetadata) []string {
+	var rules []string
+
+	for _, field := range metadata.Fields {
+		if !isWritableInAny(field) {
+			continue
+		}
+
+		var parts []string
+
+		var required []string
+		if field.Required {
+			for _, method := range []string{"POST", "PUT"} {
+				if isWritableField(field, method) {
+					required = append(required, strconv.Quote(method))
+				}
+			}
+		}
+		if len(required) > 0 {
+			parts = append(parts, "Required: []string{"+strings.Join(required, ", ")+"}")
+		}
+
+		if _, ok := field.Validations["email"]; ok {
+			parts = append(parts, "Email: true")
+		}
+		if _, ok := field.Validations["url"]; ok {
+			parts = append(parts, "URL: true")
+		}
+		if pattern := field.Validations["pattern"]; pattern != "" {
+			parts = append(parts, "Pattern: "+strconv.Quote(pattern))
+		}
+		if enum := field.EnumValues(); len(enum) > 0 {
+			parts = append(parts, "Enum: []string{"+quoteAll(enum)+"}")
+		}
+		if value, ok := numberValidation(field, "min"); ok {
+			parts = append(parts, "Min: validation.Number("+formatNumber(value)+")")
+		}
+		if value, ok := numberValidation(field, "max"); ok {
+			parts = append(parts, "Max: validation.Number("+formatNumber(value)+")")
+		}
+		if value, ok := lengthValidation(field, "minLength"); ok {
+			parts = append(parts, "MinLength: "+strconv.Itoa(value))
+		}
+		if value, ok := lengthValidation(field, "maxLength"); ok {
+			parts = append(parts, "MaxLength: "+strconv.Itoa(value))
+		}
+
+		if len(parts) == 0 {
+			continue
+		}
+
+		head := fmt.Sprintf("Field: %q, Column: %q", field.JSONName(), field.ColumnName())
+		rules = append(rules, "{"+head+", "+strings.Join(parts, ", ")+"}")
+	}
+
+	return rules
+}
+
+func numberValidation(field parser.FieldMetadata, key string) (float64, bool) {
+	raw, ok := field.Validations[key]
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, false
+	}
+	return value, true
+}
+
+func lengthValidation(field parser.FieldMetadata, key string) (int, bool) {
+	raw, ok := field.Validations[key]
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,243 @@
+package generator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Dalistor/gaver/pkg/parser"
+)
+
+// handlerTestData monta os corpos e casos dos testes HTTP a partir das
+// annotations: valores válidos respeitam enum, email, url, min/max e tamanho,
+// e cada regra gera um caso inválido
+func handlerTestData(metadata *parser.ModelMetadata, data *ModuleHandlerTestData) {
+	for _, field := range metadata.Fields {
+		if !isWritableInAny(field) {
+			continue
+		}
+
+		value, ok := validValue(field)
+		if !ok {
+			fmt.Printf("⚠️  Campo %s: não foi possível gerar um valor para pattern:%s, o campo fica fora dos testes do handler\n",
+				field.Name, field.Validations["pattern"])
+			continue
+		}
+		data.Payload = append(data.Payload, value)
+
+		// Datas voltam em outro formato: não dá para comparar o texto
+		comparable := baseType(field) != "time.Time" && field.IsReadable()
+		if comparable && isWritableField(field, "POST") {
+			data.CreateCompare = append(data.CreateCompare, field.JSONName())
+		}
+		if comparable && isWritableField(field, "PUT") {
+			data.UpdateCompare = append(data.UpdateCompare, field.JSONName())
+		}
+
+		if isWritableField(field, "POST") {
+			data.Invalid = append(data.Invalid, invalidCases(field, "POST")...)
+		}
+		if data.PutRequired == "" && field.Required && isWritableField(field, "PUT") {
+			data.PutRequired = field.JSONName()
+		}
+		if isWritableField(field, "PATCH") {
+			if data.PatchField == nil && comparable && value.Varies {
+				patch := value
+				data.PatchField = &patch
+			}
+			if cases := invalidCases(field, "PATCH"); data.PatchInvalid == nil && len(cases) > 0 {
+				data.PatchInvalid = &cases[0]
+			}
+		}
+		// Filtros chegam como texto: só campos texto comparam igual em todo banco
+		if data.FilterField == nil && field.IsFilterable() && (baseType(field) == "string" || baseType(field) == "uuid.UUID") {
+			filter := value
+			data.FilterField = &filter
+		}
+	}
+
+	for _, field := range metadata.Fields {
+		if field.JSONName() == "" || isPrimaryKey(field) {
+			continue
+		}
+		if !field.IsReadable() {
+			data.Hidden = append(data.Hidden, field.JSONName())
+			continue
+		}
+		if field.Owner || !field.IsScalar() || isWritableField(field, "POST") {
+			continue
+		}
+		if expr := ignoredValue(field); expr != "" {
+			data.ReadOnly = append(data.ReadOnly, TestFieldData{JSON: field.JSONName(), Expr: expr})
+		}
+	}
+}
+
+func baseType(field parser.FieldMetadata) string {
+	return strings.TrimPrefix(field.Type, "*")
+}
+
+func isInteger(field parser.FieldMetadata) bool {
+	return strings.HasPrefix(baseType(field), "int") || strings.HasPrefix(baseType(field), "uint")
+}
+
+func isNumber(field parser.FieldMetadata) bool {
+	return isInteger(field) || strings.HasPrefix(baseType(field), "float")
+}
+
+// validValue gera a expressão de um valor válido para o campo. n (1, 2, ...)
+// diferencia os registros criados no mesmo teste, para campos unique.
+// Retorna false quando não há como gerar um texto que atenda o pattern.
+func validValue(field parser.FieldMetadata) (TestFieldData, bool) {
+	value := TestFieldData{JSON: field.JSONName()}
+
+	switch {
+	case isNumber(field):
+		value.Expr, value.Varies = numberValue(field)
+
+	case baseType(field) == "bool":
+		value.Expr, value.Varies = "n%2 == 1", true
+
+	case baseType(field) == "time.Time":
+		value.Expr, value.Varies = "testDate(n)", true
+
+	case baseType(field) == "uuid.UUID":
+		value.Expr, value.Varies = `"00000000-0000-0000-0000-00000000000" + itoa(n)`, true
+
+	case len(field.EnumValues()) > 0:
+		enum := field.EnumValues()
+		value.Expr = "[]string{" + quoteAll(enum) + "}[(n-1)%" + strconv.Itoa(len(enum)) + "]"
+		value.Varies = len(enum) > 1
+
+	case hasValidation(field, "email"):
+		value.Expr, value.Varies = `"teste" + itoa(n) + "@example.com"`, true
+
+	case hasValidation(field, "url"):
+		value.Expr, value.Varies = `"https://example.com/" + itoa(n)`, true
+
+	case field.Validations["pattern"] != "":
+		values := patternValues(field, 3)
+		switch len(values) {
+		case 0:
+			return value, false
+		case 1:
+			value.Expr = strconv.Quote(values[0])
+		default:
+			value.Expr = "[]string{" + quoteAll(values) + "}[(n-1)%" + strconv.Itoa(len(values)) + "]"
+			value.Varies = true
+		}
+
+	default:
+		value.Expr, value.Varies = stringValue(field), true
+	}
+
+	return value, true
+}
+
+func hasValidation(field parser.FieldMetadata, key string) bool {
+	_, ok := field.Validations[key]
+	return ok
+}
+
+// numberValue parte do min (ou 1) e soma n-1 quando não há max
+func numberValue(field parser.FieldMetadata) (string, bool) {
+	base := 1.0
+	minValue, hasMin := numberValidation(field, "min")
+	maxValue, hasMax := numberValidation(field, "max")
+	if hasMin {
+		base = minValue
+	} else if hasMax && maxValue < base {
+		base = maxValue
+	}
+	if isInteger(field) {
+		base = math.Ceil(base)
+	}
+
+	if hasMax {
+		return formatNumber(base), false
+	}
+
+	step := "float64(n-1)"
+	if isInteger(field) {
+		step = "n - 1"
+	}
+	if base == 0 {
+		return step, true
+	}
+	return formatNumber(base) + " + " + step, true
+}
+
+// stringValue gera um texto de tamanho entre minLength e maxLength (padrão 6)
+// terminado em n
+func stringValue(field parser.FieldMetadata) string {
+	length := 6
+	if minLength, ok := lengthValidation(field, "minLength"); ok && minLength > length {
+		length = minLength
+	}
+	if maxLength, ok := lengthValidation(field, "maxLength"); ok && maxLength < length {
+		length = maxLength
+	}
+
+	prefix := ("teste" + strings.Repeat("a", length))[:length-1]
+	if prefix == "" {
+		return "itoa(n)"
+	}
+	return strconv.Quote(prefix) + " + itoa(n)"
+}
+
+// invalidCases gera um corpo inválido para cada regra do campo no método
+func invalidCases(field parser.FieldMetadata, method string) []TestInvalidData {
+	name := field.JSONName()
+	var cases []TestInvalidData
+
+	if field.Required && method != "PATCH" {
+		cases = append(cases, TestInvalidData{Name: name + " obrigatório", JSON: name})
+	}
+
+	if enum := field.EnumValues(); len(enum) > 0 && !slices.Contains(enum, "valor_invalido") {
+		cases = append(cases, TestInvalidData{Name: name + " fora do enum", JSON: name, Expr: `"valor_invalido"`})
+	}
+	if hasValidation(field, "email") {
+		cases = append(cases, TestInvalidData{Name: name + " com email inválido", JSON: name, Expr: `"email_invalido"`})
+	}
+	if hasValidation(field, "url") {
+		cases = append(cases, TestInvalidData{Name: name + " com URL inválida", JSON: name, Expr: `"url_invalida"`})
+	}
+
+	if isNumber(field) {
+		if value, ok := numberValidation(field, "min"); ok {
+			cases = append(cases, TestInvalidData{Name: name + " abaixo do mínimo", JSON: name, Expr: formatNumber(value - 1)})
+		}
+		if value, ok := numberValidation(field, "max"); ok {
+			cases = append(cases, TestInvalidData{Name: name + " acima do máximo", JSON: name, Expr: formatNumber(value + 1)})
+		}
+	}
+
+	// Tamanho só para textos livres: enum, email e pattern já têm valor próprio
+	if baseType(field) == "string" && len(field.EnumValues()) == 0 && !hasValidation(field, "email") && field.Validations["pattern"] == "" {
+		if value, ok := lengthValidation(field, "minLength"); ok && value > 1 {
+			cases = append(cases, TestInvalidData{Name: name + " curto", JSON: name, Expr: strconv.Quote(strings.Repeat("a", value-1))})
+		}
+		if value, ok := lengthValidation(field, "maxLength"); ok {
+			cases = append(cases, TestInvalidData{Name: name + " longo", JSON: name, Expr: strconv.Quote(strings.Repeat("a", value+1))})
+		}
+	}
+
+	return cases
+}
+
+// ignoredValue gera um valor para campos que o POST deve ignorar ("" quando
+// não há valor distinguível do padrão)
+func ignoredValue(field parser.FieldMetadata) string {
+	switch {
+	case isNumber(field):
+		return "987654"
+	case baseType(field) == "string":
+		return `"valor_ignorado"`
+	case baseType(field) == "uuid.UUID":
+		return `"11111111-1111-1111-1111-111111111111"`
+	}
+	return ""
+}
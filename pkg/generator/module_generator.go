@@ -1,9 +1,14 @@
 package generator
 
 import (
+	"fmt"
+	"go/format"
+	"os"
 	"path/filepath"
+	"slices"
 	
 	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/parser"
 )
 
 // ModuleGenerator gera código para módulos
@@ -22,6 +27,10 @@ func NewModuleGenerator(templatesPath, projectName string) *ModuleGenerator {
 
 // GenerateHandler gera um handler usando template
 func (g *ModuleGenerator) GenerateHandler(moduleName, modelName string, methods map[string]bool) error {
+	return g.generateHandler(moduleName, modelName, methods, nil, nil)
+}
+
+func (g *ModuleGenerator) generateHandler(moduleName, modelName string, methods map[string]bool, filters []FilterFieldData, owner *OwnerData, options ...func(*ModuleHandlerData)) error {
 	gen := templates.New("modules")
 
 	data := ModuleHandlerData{
@@ -35,6 +44,11 @@ func (g *ModuleGenerator) GenerateHandler(moduleName, modelName string, methods
 		HasUpdate:      methods["update"],
 		HasPatch:       methods["patch"],
 		HasDelete:      methods["delete"],
+		FilterFields:   filters,
+		Owner:          owner,
+	}
+	for _, option := range options {
+		option(&data)
 	}
 
 	outputPath := filepath.Join(moduleName, "handlers", ToSnakeCase(modelName)+"_handler.go")
@@ -43,6 +57,15 @@ func (g *ModuleGenerator) GenerateHandler(moduleName, modelName string, methods
 
 // GenerateService gera um service usando template
 func (g *ModuleGenerator) GenerateService(moduleName, modelName string, methods map[string]bool) error {
+	return g.generateService(moduleName, modelName, methods, nil)
+}
+
+// GenerateServiceWithMetadata gera o service usando metadata do model parseado
+func (g *ModuleGenerator) GenerateServiceWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
+	return g.generateService(moduleName, modelName, methods, ownerData(metadata))
+}
+
+func (g *ModuleGenerator) generateService(moduleName, modelName string, methods map[string]bool, owner *OwnerData) error {
 	gen := templates.New("modules")
 
 	data := ModuleServiceData{
@@ -55,6 +78,7 @@ func (g *ModuleGenerator) GenerateService(moduleName, modelName string, methods
 		HasCreate:      methods["create"],
 		HasUpdate:      methods["update"],
 		HasDelete:      methods["delete"],
+		Owner:          owner,
 	}
 
 	outputPath := filepath.Join(moduleName, "services", ToSnakeCase(modelName)+"_service.go")
@@ -63,6 +87,15 @@ func (g *ModuleGenerator) GenerateService(moduleName, modelName string, methods
 
 // GenerateRepository gera um repository usando template
 func (g *ModuleGenerator) GenerateRepository(moduleName, modelName string, methods map[string]bool) error {
+	return g.generateRepository(moduleName, modelName, methods, nil, false)
+}
+
+// GenerateRepositoryWithMetadata gera o repository usando metadata do model parseado
+func (g *ModuleGenerator) GenerateRepositoryWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
+	return g.generateRepository(moduleName, modelName, methods, ownerData(metadata), hasUUIDKey(metadata))
+}
+
+func (g *ModuleGenerator) generateRepository(moduleName, modelName string, methods map[string]bool, owner *OwnerData, uuidKey bool) error {
 	gen := templates.New("modules")
 
 	data := ModuleRepositoryData{
@@ -75,16 +108,138 @@ func (g *ModuleGenerator) GenerateRepository(moduleName, modelName string, metho
 		HasCreate:      methods["create"],
 		HasUpdate:      methods["update"],
 		HasDelete:      methods["delete"],
+		Owner:          owner,
+		UUIDKey:        uuidKey,
 	}
 
 	outputPath := filepath.Join(moduleName, "repositories", ToSnakeCase(modelName)+"_repository.go")
-	return gen.Generate("module_repository.tmpl", outputPath, data)
+	if err := gen.Generate("module_repository.tmpl", outputPath, data); err != nil {
+		return err
+	}
+
+	// O mock em memória sai dos mesmos dados, para acompanhar a interface
+	mocksPath := filepath.Join(moduleName, "repositories", "mocks")
+	if err := gen.Generate("module_repository_mocks_store.tmpl", filepath.Join(mocksPath, "store.go"), data); err != nil {
+		return err
+	}
+	return gen.Generate("module_repository_mock.tmpl", filepath.Join(mocksPath, ToSnakeCase(modelName)+"_repository.go"), data)
 }
 
 // GenerateHandlerWithMetadata gera handler usando metadata do model parseado
-func (g *ModuleGenerator) GenerateHandlerWithMetadata(moduleName, modelName string, metadata interface{}, methods map[string]bool) error {
-	// Por enquanto, usa o gerador normal
-	// TODO: Implementar geração mais inteligente baseada em metadata
-	return g.GenerateHandler(moduleName, modelName, methods)
+func (g *ModuleGenerator) GenerateHandlerWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
+	// Campos readable simples podem ser usados como filtro na listagem
+	var filters []FilterFieldData
+	for _, field := range metadata.Fields {
+		if field.IsFilterable() {
+			filters = append(filters, FilterFieldData{
+				Param:  field.JSONName(),
+				Column: field.ColumnName(),
+			})
+		}
+	}
+
+	return g.generateHandler(moduleName, modelName, methods, filters, ownerData(metadata), func(data *ModuleHandlerData) {
+		data.Writable = writableData(metadata)
+		data.Rules = validationRules(metadata)
+		data.HiddenFields = hiddenFields(metadata)
+	})
 }
 
+// GenerateHandlerTestWithMetadata gera os testes HTTP do handler
+// (handlers/<model>_handler_test.go), com as rotas em resourcePath, e os
+// helpers compartilhados pelos testes do módulo (handlers/helpers_test.go)
+func (g *ModuleGenerator) GenerateHandlerTestWithMetadata(moduleName, modelName, resourcePath string, metadata *parser.ModelMetadata, methods map[string]bool) error {
+	gen := templates.New("modules")
+
+	data := ModuleHandlerTestData{
+		ProjectName:    g.projectName,
+		ModuleName:     moduleName,
+		ModelName:      modelName,
+		ModelNameLower: ToLower(modelName),
+		ResourcePath:   resourcePath,
+		HasList:        methods["list"],
+		HasGet:         methods["get"],
+		HasCreate:      methods["create"],
+		HasUpdate:      methods["update"],
+		HasPatch:       methods["patch"],
+		HasDelete:      methods["delete"],
+	}
+	handlerTestData(metadata, &data)
+	data.Protected = protectedRoutes(metadata, resourcePath, methods)
+
+	handlersPath := filepath.Join(moduleName, "handlers")
+	if err := gen.Generate("module_handler_test_helpers.tmpl", filepath.Join(handlersPath, "helpers_test.go"), data); err != nil {
+		return err
+	}
+	return gen.Generate("module_handler_test.tmpl", filepath.Join(handlersPath, ToSnakeCase(modelName)+"_handler_test.go"), data)
+}
+
+// GenerateFactory gera a factory do model (factories/<model>_factory.go),
+// criando as relações obrigatórias com as factories em parents
+func (g *ModuleGenerator) GenerateFactory(moduleName, modelName string, metadata *parser.ModelMetadata, parents []FactoryParent) (string, error) {
+	data := FactoryData{
+		ProjectName: g.projectName,
+		ModuleName:  moduleName,
+		ModelName:   modelName,
+	}
+	if err := factoryData(metadata, moduleName, parents, &data); err != nil {
+		return "", err
+	}
+
+	src, err := templates.Render("module_factory.tmpl", data)
+	if err != nil {
+		return "", err
+	}
+	// Alinhamento dos campos depende dos nomes: formatar como o gofmt
+	formatted, err := format.Source(src)
+	if err != nil {
+		return "", fmt.Errorf("erro ao formatar factory: %w", err)
+	}
+
+	path := filepath.Join("modules", moduleName, "factories", ToSnakeCase(modelName)+"_factory.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("erro ao criar diretório: %w", err)
+	}
+	return path, os.WriteFile(path, formatted, 0644)
+}
+
+// ownerData monta os dados do campo owner e das ações com permissão owner
+// (nil se o model não tiver campo owner)
+func ownerData(metadata *parser.ModelMetadata) *OwnerData {
+	field := metadata.OwnerField()
+	if field == nil {
+		return nil
+	}
+
+	owner := &OwnerData{
+		Column: field.ColumnName(),
+		JSON:   field.JSONName(),
+	}
+
+	for _, action := range parser.PermissionActions {
+		roles := metadata.PermissionFor(action)
+		if !slices.Contains(roles, parser.PermissionOwner) || slices.Contains(roles, parser.PermissionAny) || slices.Contains(roles, parser.PermissionAuth) {
+			continue
+		}
+
+		scope := OwnerScopeData{Action: action}
+		for _, role := range roles {
+			if role != parser.PermissionOwner {
+				scope.Bypass = append(scope.Bypass, role)
+			}
+		}
+		owner.Scopes = append(owner.Scopes, scope)
+	}
+
+	return owner
+}
+
+// hasUUIDKey verifica se o model usa o ID uuid.UUID do template de model
+func hasUUIDKey(metadata *parser.ModelMetadata) bool {
+	for _, field := range metadata.Fields {
+		if field.Name == "ID" {
+			return field.Type == "uuid.UUID"
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Dalistor/gaver/pkg/parser"
+)
+
+// patternChars são os caracteres preferidos ao escolher um caractere de uma
+// classe ([A-Z], \d, [^,]...)
+const patternChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// patternValues gera até count textos distintos que atendem ao pattern do
+// campo e a minLength/maxLength. Cada variação escolhe outro caractere das
+// classes e outra alternativa de "|"; as repetições crescem até alcançar o
+// minLength. Retorna nil se o pattern for inválido ou se nenhum texto gerado
+// for aceito pelo validador.
+func patternValues(field parser.FieldMetadata, count int) []string {
+	pattern := field.Validations["pattern"]
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	tree, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+
+	minLength, hasMin := lengthValidation(field, "minLength")
+	maxLength, hasMax := lengthValidation(field, "maxLength")
+
+	var values []string
+	for variant := 0; variant < count; variant++ {
+		for extra := 0; extra <= max(minLength, 0); extra++ {
+			var value strings.Builder
+			writePattern(&value, tree, variant, extra)
+
+			text := value.String()
+			length := utf8.RuneCountInString(text)
+			if !re.MatchString(text) || (hasMin && length < minLength) || (hasMax && length > maxLength) {
+				continue
+			}
+			if !slices.Contains(values, text) {
+				values = append(values, text)
+			}
+			break
+		}
+	}
+	return values
+}
+
+// writePattern escreve um texto aceito pelo nó, com extra repetições além do
+// mínimo em cada *, + e {n,m}
+func writePattern(value *strings.Builder, node *syntax.Regexp, variant, extra int) {
+	switch node.Op {
+	case syntax.OpLiteral:
+		value.WriteString(string(node.Rune))
+
+	case syntax.OpCharClass:
+		if r, ok := classRune(node.Rune, variant); ok {
+			value.WriteRune(r)
+		}
+
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		value.WriteByte(patternChars[10+variant%26])
+
+	case syntax.OpCapture:
+		writePattern(value, node.Sub[0], variant, extra)
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpRepeat:
+		times := node.Min + extra
+		switch {
+		case node.Op == syntax.OpStar:
+			times = extra
+		case node.Op == syntax.OpPlus:
+			times = 1 + extra
+		case node.Max >= 0 && times > node.Max:
+			times = node.Max
+		}
+		for i := 0; i < times; i++ {
+			writePattern(value, node.Sub[0], variant, extra)
+		}
+
+	case syntax.OpConcat:
+		for _, sub := range node.Sub {
+			writePattern(value, sub, variant, extra)
+		}
+
+	case syntax.OpAlternate:
+		writePattern(value, node.Sub[variant%len(node.Sub)], variant, extra)
+	}
+	// OpQuest, âncoras e \b não escrevem nada
+}
+
+// classRune escolhe um caractere da classe, de preferência letra ou dígito
+func classRune(ranges []rune, variant int) (rune, bool) {
+	var candidates []rune
+	for _, r := range patternChars {
+		if inRanges(ranges, r) {
+			candidates = append(candidates, r)
+		}
+	}
+
+	// Classes sem letras nem dígitos ([-_.], \s...): o primeiro imprimível
+	if len(candidates) == 0 {
+		for i := 0; i+1 < len(ranges) && len(candidates) < len(patternChars); i += 2 {
+			for r := max(ranges[i], ' '); r <= ranges[i+1] && len(candidates) < len(patternChars); r++ {
+				if unicode.IsPrint(r) {
+					candidates = append(candidates, r)
+				}
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return 0, false
+	}
+	return candidates[variant%len(candidates)], true
+}
+
+func inRanges(ranges []rune, r rune) bool {
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if r >= ranges[i] && r <= ranges[i+1] {
+			return true
+		}
+	}
+	return false
+}
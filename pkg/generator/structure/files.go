@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	templates "github.com/Dalistor/gaver/internal/templates"
 	"github.com/Dalistor/gaver/pkg/config"
@@ -16,14 +17,30 @@ func CreateProjectFolders(projectName string) error {
 	dirs := []string{
 		projectName,
 		filepath.Join(projectName, "cmd", "server"),
+		filepath.Join(projectName, "cmd", "seed"),
 		filepath.Join(projectName, "config", "env"),
 		filepath.Join(projectName, "config", "middlewares"),
 		filepath.Join(projectName, "config", "cors"),
+		filepath.Join(projectName, "config", "auth"),
+		filepath.Join(projectName, "config", "apikeys"),
+		filepath.Join(projectName, "config", "ratelimit"),
 		filepath.Join(projectName, "config", "database"),
 		filepath.Join(projectName, "config", "database", "migrations"),
 		filepath.Join(projectName, "config", "routines"),
+		filepath.Join(projectName, "config", "jobs"),
 		filepath.Join(projectName, "config", "routes"),
 		filepath.Join(projectName, "config", "modules"),
+		filepath.Join(projectName, "config", "docs"),
+		filepath.Join(projectName, "config", "health"),
+		filepath.Join(projectName, "config", "metrics"),
+		filepath.Join(projectName, "config", "logger"),
+		filepath.Join(projectName, "config", "tracing"),
+		filepath.Join(projectName, "config", "validation"),
+		filepath.Join(projectName, "config", "app"),
+		filepath.Join(projectName, "config", "testutil"),
+		filepath.Join(projectName, "config", "factory"),
+		filepath.Join(projectName, "seeders"),
+		filepath.Join(projectName, "tests"),
 		filepath.Join(projectName, "modules"),
 		filepath.Join(projectName, "migrations"),
 	}
@@ -45,6 +62,7 @@ type ProjectConfig struct {
 	DatabaseUser         string
 	ProjectType          string
 	ServerPort           string
+	JWTSecret            string
 }
 
 // GenerateInitialFiles gera arquivos iniciais do projeto
@@ -54,6 +72,8 @@ func GenerateInitialFiles(projectName, database, projectType string) error {
 		return fmt.Errorf("erro ao criar pastas: %w", err)
 	}
 
+	jwtSecret := config.GenerateSecret()
+
 	config := ProjectConfig{
 		ProjectName:          projectName,
 		DatabaseDriver:       getDatabaseDriver(database),
@@ -62,26 +82,67 @@ func GenerateInitialFiles(projectName, database, projectType string) error {
 		DatabaseUser:         getDatabaseUser(database),
 		ProjectType:          projectType,
 		ServerPort:           "8080", // Porta padrão do servidor
+		JWTSecret:            jwtSecret,
 	}
 
 	gen := templates.New(projectName)
 
 	// Gerar arquivos de config
 	files := map[string]string{
-		"config_env.tmpl":         "config/env/env.go",
-		"config_middlewares.tmpl": "config/middlewares/middlewares.go",
-		"config_cors.tmpl":        "config/cors/cors.go",
-		"config_database.tmpl":    "config/database/database.go",
-		"migration_table.tmpl":    "config/database/migrations/migrations.go",
-		"routines.tmpl":           "config/routines/routines.go",
-		"config_routes.tmpl":      "config/routes/routes.go",
-		"config_modules.tmpl":     "config/modules/modules.go",
-		"main.tmpl":               "cmd/server/main.go",
-		"env.tmpl":                ".env",
-		"env_example.tmpl":        ".env.example",
-		"gitignore.tmpl":          ".gitignore",
-		"go_mod.tmpl":             "go.mod",
-		"readme.tmpl":             "README.md",
+		"config_env.tmpl":                 "config/env/env.go",
+		"config_middlewares.tmpl":         "config/middlewares/middlewares.go",
+		"config_cors.tmpl":                "config/cors/cors.go",
+		"config_auth.tmpl":                "config/auth/auth.go",
+		"config_apikeys.tmpl":             "config/apikeys/apikeys.go",
+		"config_apikeys_model.tmpl":       "config/apikeys/api_key.go",
+		"config_ratelimit.tmpl":           "config/ratelimit/ratelimit.go",
+		"config_ratelimit_memory.tmpl":    "config/ratelimit/memory.go",
+		"config_ratelimit_database.tmpl":  "config/ratelimit/database.go",
+		"config_database.tmpl":            "config/database/database.go",
+		"migration_table.tmpl":            "config/database/migrations/migrations.go",
+		"routines.tmpl":                   "config/routines/routines.go",
+		"routines_schedule.tmpl":          "config/routines/schedule.go",
+		"routines_lock.tmpl":              "config/routines/lock.go",
+		"routines_history.tmpl":           "config/routines/history.go",
+		"routines_admin.tmpl":             "config/routines/admin.go",
+		"config_jobs.tmpl":                "config/jobs/jobs.go",
+		"config_jobs_model.tmpl":          "config/jobs/job.go",
+		"config_jobs_worker.tmpl":         "config/jobs/worker.go",
+		"config_jobs_handlers.tmpl":       "config/jobs/handlers.go",
+		"config_jobs_worker_test.tmpl":    "config/jobs/worker_test.go",
+		"config_routes.tmpl":              "config/routes/routes.go",
+		"config_modules.tmpl":             "config/modules/modules.go",
+		"config_docs.tmpl":                "config/docs/docs.go",
+		"config_logger.tmpl":              "config/logger/logger.go",
+		"config_logger_gorm.tmpl":         "config/logger/gorm.go",
+		"config_database_tx.tmpl":         "config/database/tx.go",
+		"config_tracing.tmpl":             "config/tracing/tracing.go",
+		"config_tracing_gorm.tmpl":        "config/tracing/gorm.go",
+		"config_app.tmpl":                 "config/app/app.go",
+		"config_testutil.tmpl":            "config/testutil/testutil.go",
+		"config_testutil_client.tmpl":     "config/testutil/client.go",
+		"config_testutil_response.tmpl":   "config/testutil/response.go",
+		"tests_main.tmpl":                 "tests/main_test.go",
+		"config_factory.tmpl":             "config/factory/factory.go",
+		"config_factory_fake.tmpl":        "config/factory/fake.go",
+		"seeders_runner.tmpl":             "seeders/runner.go",
+		"seeders.tmpl":                    "seeders/seeders.go",
+		"seed_main.tmpl":                  "cmd/seed/main.go",
+		"config_validation.tmpl":          "config/validation/validation.go",
+		"config_metrics.tmpl":             "config/metrics/metrics.go",
+		"config_metrics_gorm.tmpl":        "config/metrics/gorm.go",
+		"config_health.tmpl":              "config/health/health.go",
+		"config_health_checks.tmpl":       "config/health/checks.go",
+		"config_health_disk.tmpl":         "config/health/disk_unix.go",
+		"config_health_disk_windows.tmpl": "config/health/disk_windows.go",
+		"config_health_disk_other.tmpl":   "config/health/disk_other.go",
+		"openapi_json.tmpl":               "config/docs/openapi.json",
+		"main.tmpl":                       "cmd/server/main.go",
+		"env.tmpl":                        ".env",
+		"env_example.tmpl":                ".env.example",
+		"gitignore.tmpl":                  ".gitignore",
+		"go_mod.tmpl":                     "go.mod",
+		"readme.tmpl":                     "README.md",
 	}
 
 	for template, output := range files {
@@ -90,9 +151,25 @@ func GenerateInitialFiles(projectName, database, projectType string) error {
 		}
 	}
 
+	// Tabelas internas (fila de jobs, rotinas e rate limit), aplicadas com
+	// 'gaver migrate up' como as tabelas dos módulos
+	// 'gaver migrate' identifica cada migration pelo timestamp: um segundo por tabela
+	now := time.Now()
+	for i, table := range frameworkTables {
+		timestamp := now.Add(time.Duration(i) * time.Second).Format("20060102_150405")
+		migration := filepath.Join("migrations", timestamp+"_create_"+table+".sql")
+		if err := gen.Generate("migration_create_"+table+".tmpl", migration, config); err != nil {
+			return fmt.Errorf("erro ao gerar %s: %w", migration, err)
+		}
+	}
+
 	return nil
 }
 
+// frameworkTables são as tabelas do config/ criadas por migrations na
+// inicialização do projeto (templates migration_create_<tabela>.tmpl)
+var frameworkTables = []string{"jobs", "routine_locks", "routine_executions", "rate_limit_counters"}
+
 func getDatabaseDriver(db string) string {
 	drivers := map[string]string{
 		"postgres": "postgres",
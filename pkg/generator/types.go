@@ -1,5 +1,7 @@
 package generator
 
+import "github.com/Dalistor/gaver/pkg/parser"
+
 // ModelData contém dados para gerar um model
 type ModelData struct {
 	PackageName string
@@ -48,6 +50,37 @@ type ModuleHandlerData struct {
 	HasUpdate     bool
 	HasPatch      bool
 	HasDelete     bool
+	FilterFields  []FilterFieldData
+	Owner         *OwnerData
+	Writable      []WritableMethodData // vazio = aceita todos os campos
+	Rules         []string             // literais validation.Rule das annotations
+	HiddenFields  []string             // campos do model fora da resposta (sem readable)
+}
+
+// WritableMethodData lista os campos aceitos no corpo de um método HTTP
+type WritableMethodData struct {
+	Method string
+	Fields []FilterFieldData // Param = nome no JSON
+}
+
+// FilterFieldData representa um parâmetro de query aceito como filtro na listagem
+type FilterFieldData struct {
+	Param  string
+	Column string
+}
+
+// OwnerData descreve o campo com o dono do registro (annotation owner)
+type OwnerData struct {
+	Column string
+	JSON   string
+	Scopes []OwnerScopeData // ações restritas aos registros do usuário
+}
+
+// OwnerScopeData é uma ação com permissão owner e os papéis que não ficam
+// restritos aos próprios registros (ex.: update=admin|owner -> admin)
+type OwnerScopeData struct {
+	Action string
+	Bypass []string
 }
 
 // ModuleServiceData contém dados para gerar um service de módulo
@@ -61,6 +94,7 @@ type ModuleServiceData struct {
 	HasCreate      bool
 	HasUpdate      bool
 	HasDelete      bool
+	Owner          *OwnerData
 }
 
 // ModuleRepositoryData contém dados para gerar um repository de módulo
@@ -74,6 +108,58 @@ type ModuleRepositoryData struct {
 	HasCreate      bool
 	HasUpdate      bool
 	HasDelete      bool
+	Owner          *OwnerData
+	UUIDKey        bool // chave primária uuid.UUID, gerada no Create
+}
+
+// ModuleHandlerTestData contém dados para gerar os testes HTTP de um handler
+type ModuleHandlerTestData struct {
+	ProjectName    string
+	ModuleName     string
+	ModelName      string
+	ModelNameLower string
+	ResourcePath   string
+	HasList        bool
+	HasGet         bool
+	HasCreate      bool
+	HasUpdate      bool
+	HasPatch       bool
+	HasDelete      bool
+	Payload        []TestFieldData   // corpo válido, em função de n
+	CreateCompare  []string          // campos devolvidos como enviados no POST
+	UpdateCompare  []string          // campos devolvidos como enviados no PUT
+	Hidden         []string          // campos sem readable (vazios na resposta)
+	ReadOnly       []TestFieldData   // campos readable não aceitos no POST
+	Invalid        []TestInvalidData // corpos inválidos para o POST
+	PutRequired    string            // campo obrigatório no PUT ("" se nenhum)
+	PatchField     *TestFieldData    // campo alterado no teste de PATCH
+	PatchInvalid   *TestInvalidData
+	FilterField    *TestFieldData  // campo usado no teste de filtro da listagem
+	Protected      []TestRouteData // rotas que exigem login (permissions)
+}
+
+// TestRouteData é uma rota protegida montada com os middlewares de permissions
+type TestRouteData struct {
+	Method      string
+	Path        string // caminho registrado (/products/:id)
+	Request     string // caminho requisitado (/products/1)
+	Handler     string
+	Middlewares string
+}
+
+// TestFieldData é um campo do corpo gerado para os testes
+type TestFieldData struct {
+	JSON   string
+	Expr   string // expressão Go do valor (pode usar n)
+	Varies bool   // Expr muda com n
+	Note   string // comentário quando o valor precisa de ajuste manual
+}
+
+// TestInvalidData é uma alteração no corpo válido que a validação deve recusar
+type TestInvalidData struct {
+	Name   string
+	JSON   string
+	Expr   string // "" remove o campo
 }
 
 // ModuleInitData contém dados para gerar module.go inicial
@@ -95,3 +181,49 @@ type ModelFieldData struct {
 	GORMTag    string
 	Annotation string
 }
+
+// FactoryData contém dados para gerar a factory de um model
+type FactoryData struct {
+	ProjectName     string
+	ModuleName      string
+	ModelName       string
+	StdImports      []string
+	ProjectImports  []string // imports com alias das factories de outros módulos
+	ExternalImports []string
+	Fields          []FactoryFieldData
+	Relations       []FactoryRelationData
+}
+
+// FactoryFieldData é um campo preenchido pela definição da factory
+type FactoryFieldData struct {
+	Name string
+	Expr string // expressão Go do valor (pode usar seq)
+	Note string // comentário quando o valor precisa de ajuste manual
+}
+
+// FactoryRelationData é uma relação obrigatória criada pela factory do
+// model relacionado antes de gravar o registro
+type FactoryRelationData struct {
+	Type    string // belongsTo
+	Model   string
+	Field   string // campo com a chave estrangeira
+	Factory string // expressão da factory do model relacionado
+	Var     string
+	Zero    string // valor zero da chave
+	Assign  string // expressão da chave a partir de Var
+}
+
+// RequiredRelation é uma relação belongsTo que o registro precisa ter para
+// ser gravado: chave required ou sem ponteiro
+type RequiredRelation struct {
+	Type  string
+	Model string
+	Field parser.FieldMetadata // campo com a chave estrangeira
+}
+
+// FactoryParent é o model de uma RequiredRelation, localizado no projeto
+type FactoryParent struct {
+	RequiredRelation
+	Module string
+	IDType string // tipo da chave primária do model relacionado
+}
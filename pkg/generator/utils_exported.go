@@ -1,6 +1,10 @@
 package generator
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/Dalistor/gaver/pkg/parser"
+)
 
 // ToLower converte primeira letra para minúscula
 func ToLower(s string) string {
@@ -30,14 +34,8 @@ func Capitalize(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
-// Pluralize adiciona 's' (versão simples)
+// Pluralize pluraliza o nome com as regras dos nomes de tabela do parser
 func Pluralize(s string) string {
-	if strings.HasSuffix(s, "s") {
-		return s
-	}
-	if strings.HasSuffix(s, "y") {
-		return s[:len(s)-1] + "ies"
-	}
-	return s + "s"
+	return parser.Pluralize(s)
 }
 
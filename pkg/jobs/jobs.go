@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TableName é a tabela da fila, criada pela migration create_jobs do projeto
+const TableName = "jobs"
+
+// Status de um job (mesmos valores de config/jobs do projeto)
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusDead    = "dead"
+)
+
+// Job é uma linha da tabela jobs (mesmas colunas do model config/jobs.Job
+// do projeto)
+type Job struct {
+	ID             uint `gorm:"primaryKey"`
+	Name           string
+	Payload        string
+	Status         string
+	Attempts       int
+	MaxAttempts    int
+	TimeoutSeconds int
+	RunAt          time.Time
+	LockedBy       string
+	LockedUntil    *time.Time
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+}
+
+// TableName usa a tabela jobs
+func (Job) TableName() string {
+	return TableName
+}
+
+// Filter seleciona jobs em List
+type Filter struct {
+	Status string
+	Name   string
+	Limit  int
+}
+
+// EnsureTable verifica se a tabela jobs existe
+func EnsureTable(db *gorm.DB) error {
+	if !db.Migrator().HasTable(TableName) {
+		return fmt.Errorf("tabela %s não existe: execute 'gaver migrate up'", TableName)
+	}
+	return nil
+}
+
+// List retorna os jobs do filtro, dos mais recentes para os mais antigos
+func List(db *gorm.DB, filter Filter) ([]Job, error) {
+	query := db.Order("id DESC")
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Name != "" {
+		query = query.Where("name = ?", filter.Name)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var jobs []Job
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("erro ao listar jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// Retry devolve jobs dead à fila com as tentativas zeradas. Sem IDs, devolve
+// todos os jobs dead.
+func Retry(db *gorm.DB, ids []uint) (int64, error) {
+	query := db.Model(&Job{}).Where("status = ?", StatusDead)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	result := query.Updates(map[string]interface{}{
+		"status":   StatusPending,
+		"attempts": 0,
+		"run_at":   time.Now().UTC(),
+	})
+	if result.Error != nil {
+		return 0, fmt.Errorf("erro ao reenfileirar jobs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// Purge remove jobs com os status informados atualizados antes de before
+func Purge(db *gorm.DB, statuses []string, before time.Time) (int64, error) {
+	result := db.Where("status IN ? AND updated_at < ?", statuses, before.UTC()).Delete(&Job{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("erro ao remover jobs: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
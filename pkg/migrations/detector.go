@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Dalistor/gaver/pkg/parser"
+	"github.com/joho/godotenv"
 	"gorm.io/gorm"
 )
 
@@ -361,13 +362,9 @@ func (d *Detector) compareFields(model *parser.ModelMetadata, table *TableSchema
 
 // shouldSkipField verifica se um campo deve ser ignorado na comparação
 func (d *Detector) shouldSkipField(field parser.FieldMetadata) bool {
-	// Ignorar campos marcados explicitamente
-	if field.Ignore || (field.IgnoreRead && field.IgnoreWrite) {
-		return true
-	}
-
-	// Ignorar se JSON tag é "-"
-	if field.JSONTag == "-" {
+	// Ignorar campos sem coluna no banco (gorm:"-"). Campos com a annotation
+	// ignore ou json:"-" continuam sendo colunas, apenas ficam fora da API
+	if field.GORMTag == "-" || strings.HasPrefix(field.GORMTag, "-:") {
 		return true
 	}
 
@@ -378,7 +375,9 @@ func (d *Detector) shouldSkipField(field parser.FieldMetadata) bool {
 	}
 
 	// Ignorar tipos complexos que não são colunas (struct, array, etc)
-	if strings.Contains(field.Type, ".") && !strings.HasPrefix(field.Type, "time.") && !strings.HasPrefix(field.Type, "uuid.") {
+	// (ponteiros como *time.Time são colunas que aceitam NULL)
+	goType := strings.TrimPrefix(field.Type, "*")
+	if strings.Contains(goType, ".") && !strings.HasPrefix(goType, "time.") && !strings.HasPrefix(goType, "uuid.") {
 		// É um tipo customizado (não primitivo)
 		return true
 	}
@@ -458,8 +457,14 @@ func (d *Detector) GenerateMigrationFile(changes []SchemaChange, name string) (s
 	filepath := filepath.Join("migrations", filename)
 
 	// Gerar conteúdo SQL
+	// DB_DRIVER já foi carregado do .env ao ler o schema do banco
+	driver := os.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = "mysql"
+	}
+
 	sqlGenerator := NewSQLGenerator()
-	upSQL, downSQL := sqlGenerator.Generate(changes, "mysql") // TODO: Detectar driver
+	upSQL, downSQL := sqlGenerator.Generate(changes, driver)
 
 	// Criar conteúdo do arquivo
 	content := fmt.Sprintf(`-- Migration: %s
@@ -483,6 +488,24 @@ func (d *Detector) GenerateMigrationFile(changes []SchemaChange, name string) (s
 	return filename, nil
 }
 
+// GenerateCreateTableMigration gera a migration de criação da tabela de um
+// model que fica fora de modules/ (ex.: config/apikeys) e por isso não é
+// escaneado por DetectChanges
+func (d *Detector) GenerateCreateTableMigration(model *parser.ModelMetadata, name string) (string, error) {
+	// DB_DRIVER define o dialeto do SQL gerado
+	_ = godotenv.Load()
+
+	change := SchemaChange{
+		Type:        "CREATE_TABLE",
+		ModelName:   model.Name,
+		TableName:   model.TableName,
+		Description: fmt.Sprintf("Criar tabela %s", model.TableName),
+		Model:       model,
+	}
+
+	return d.GenerateMigrationFile([]SchemaChange{change}, name)
+}
+
 // TableSchema representa o schema de uma tabela no banco
 type TableSchema struct {
 	Name    string
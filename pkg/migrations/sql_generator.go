@@ -148,8 +148,9 @@ func (g *SQLGenerator) goTypeToSQL(goType string, gormTag string, driver string)
 		}
 	}
 
-	// Mapeamento de tipos Go para SQL
-	switch goType {
+	// Mapeamento de tipos Go para SQL (ponteiros usam o tipo base)
+	nullable := strings.HasPrefix(goType, "*")
+	switch strings.TrimPrefix(goType, "*") {
 	case "string":
 		return "VARCHAR(255)"
 	case "int", "int32":
@@ -170,7 +171,8 @@ func (g *SQLGenerator) goTypeToSQL(goType string, gormTag string, driver string)
 		}
 		return "TINYINT(1)"
 	case "time.Time":
-		if driver == "postgres" {
+		// Datas opcionais (*time.Time) não recebem o horário atual por padrão
+		if driver == "postgres" || nullable {
 			return "TIMESTAMP"
 		}
 		return "TIMESTAMP DEFAULT CURRENT_TIMESTAMP"
@@ -188,13 +190,9 @@ func (g *SQLGenerator) goTypeToSQL(goType string, gormTag string, driver string)
 
 // shouldSkipField verifica se um campo deve ser ignorado
 func (g *SQLGenerator) shouldSkipField(field parser.FieldMetadata) bool {
-	// Ignorar campos marcados explicitamente
-	if field.Ignore || (field.IgnoreRead && field.IgnoreWrite) {
-		return true
-	}
-
-	// Ignorar se JSON tag é "-"
-	if field.JSONTag == "-" {
+	// Ignorar campos sem coluna no banco (gorm:"-"). Campos com a annotation
+	// ignore ou json:"-" continuam sendo colunas, apenas ficam fora da API
+	if field.GORMTag == "-" || strings.HasPrefix(field.GORMTag, "-:") {
 		return true
 	}
 
@@ -208,7 +206,9 @@ func (g *SQLGenerator) shouldSkipField(field parser.FieldMetadata) bool {
 
 	// Ignorar tipos complexos (structs customizados)
 	// Mas permitir time.Time e uuid.UUID
-	if strings.Contains(field.Type, ".") && !strings.HasPrefix(field.Type, "time.") && !strings.HasPrefix(field.Type, "uuid.") {
+	// (ponteiros como *time.Time são colunas que aceitam NULL)
+	goType := strings.TrimPrefix(field.Type, "*")
+	if strings.Contains(goType, ".") && !strings.HasPrefix(goType, "time.") && !strings.HasPrefix(goType, "uuid.") {
 		return true
 	}
 
@@ -0,0 +1,67 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/migrations"
+	"github.com/Dalistor/gaver/pkg/parser"
+)
+
+// apiKeyFiles mapeia os templates do pacote config/apikeys para os arquivos gerados
+var apiKeyFiles = []struct {
+	Template string
+	Output   string
+}{
+	{"config_apikeys_model.tmpl", filepath.Join("config", "apikeys", "api_key.go")},
+	{"config_apikeys.tmpl", filepath.Join("config", "apikeys", "apikeys.go")},
+}
+
+// apiKeyMiddlewares substitui o APIKeyAuth de exemplo (aceitava qualquer key)
+var apiKeyMiddlewares = []templateFunc{
+	{Name: "APIKeyAuth", Marker: "apikeys.Validate"},
+}
+
+// AddAPIKeys prepara o projeto para API keys: gera o pacote config/apikeys
+// (projetos antigos), atualiza o middleware APIKeyAuth e gera a migration da
+// tabela api_keys. Retorna o nome do arquivo de migration.
+func AddAPIKeys() (string, error) {
+	projectName, err := getProjectName()
+	if err != nil {
+		return "", fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	existing, _ := filepath.Glob(filepath.Join("migrations", "*_create_api_keys.sql"))
+	if len(existing) > 0 {
+		return "", fmt.Errorf("migration da tabela api_keys já existe (%s)", filepath.Base(existing[0]))
+	}
+
+	data := struct{ ProjectName string }{projectName}
+	for _, file := range apiKeyFiles {
+		if _, err := os.Stat(file.Output); err == nil {
+			continue
+		}
+		if err := templates.New(".").Generate(file.Template, file.Output, data); err != nil {
+			return "", fmt.Errorf("erro ao gerar %s: %w", file.Output, err)
+		}
+	}
+
+	imports := []string{"net/http", projectName + "/config/apikeys"}
+	if err := updateMiddlewares(projectName, apiKeyMiddlewares, imports); err != nil {
+		return "", fmt.Errorf("erro ao atualizar middleware APIKeyAuth: %w", err)
+	}
+
+	metadata, err := parser.ParseModelFile(apiKeyFiles[0].Output)
+	if err != nil {
+		return "", fmt.Errorf("erro ao parsear model APIKey: %w", err)
+	}
+
+	migrationFile, err := migrations.NewDetector().GenerateCreateTableMigration(metadata, "create_api_keys")
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar migration: %w", err)
+	}
+
+	return migrationFile, nil
+}
@@ -0,0 +1,132 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/config"
+)
+
+// authFiles mapeia os templates do módulo auth para os arquivos gerados
+var authFiles = []struct {
+	Template string
+	Output   string
+}{
+	{"auth_module.tmpl", "module.go"},
+	{"auth_user_model.tmpl", filepath.Join("models", "user.go")},
+	{"auth_refresh_token_model.tmpl", filepath.Join("models", "refresh_token.go")},
+	{"auth_user_repository.tmpl", filepath.Join("repositories", "user_repository.go")},
+	{"auth_refresh_token_repository.tmpl", filepath.Join("repositories", "refresh_token_repository.go")},
+	{"module_repository_mocks_store.tmpl", filepath.Join("repositories", "mocks", "store.go")},
+	{"auth_user_repository_mock.tmpl", filepath.Join("repositories", "mocks", "user_repository.go")},
+	{"auth_refresh_token_repository_mock.tmpl", filepath.Join("repositories", "mocks", "refresh_token_repository.go")},
+	{"auth_service.tmpl", filepath.Join("services", "auth_service.go")},
+	{"auth_handler.tmpl", filepath.Join("handlers", "auth_handler.go")},
+}
+
+// AuthFiles retorna os arquivos gerados por 'gaver add auth'
+func AuthFiles() []string {
+	files := make([]string, len(authFiles))
+	for i, file := range authFiles {
+		files[i] = filepath.Join("modules", "auth", file.Output)
+	}
+	return files
+}
+
+// CreateAuthModule gera o módulo auth (usuários, login, refresh tokens), o pacote
+// config/auth com a assinatura JWT e substitui o middleware Auth de exemplo
+func CreateAuthModule() error {
+	basePath := filepath.Join("modules", "auth")
+	if _, err := os.Stat(basePath); err == nil {
+		return fmt.Errorf("módulo 'auth' já existe")
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	data := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+
+	gen := templates.New(basePath)
+	for _, file := range authFiles {
+		if err := gen.Generate(file.Template, file.Output, data); err != nil {
+			return fmt.Errorf("erro ao gerar %s: %w", file.Output, err)
+		}
+	}
+
+	if err := ensureAuthSupport(); err != nil {
+		return err
+	}
+
+	// Repositories usam database.Conn
+	if err := ensureContextSupport(); err != nil {
+		return fmt.Errorf("erro ao preparar contexto e transações: %w", err)
+	}
+
+	if err := registerModuleInConfig("auth"); err != nil {
+		fmt.Printf("⚠️  Aviso: %v\n", err)
+		fmt.Println("    Adicione manualmente o módulo em config/modules/modules.go")
+		fmt.Println("    registry.Register(\"auth\", auth.NewModule())")
+	}
+
+	return nil
+}
+
+// ensureAuthSupport prepara o projeto para rotas autenticadas: gera o pacote
+// config/auth (projetos antigos), atualiza os middlewares Auth e RequireRole e
+// adiciona as variáveis do JWT no .env
+func ensureAuthSupport() error {
+	projectName, err := getProjectName()
+	if err != nil {
+		return fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	// Projetos gerados antes do config/auth existir
+	authConfig := filepath.Join("config", "auth", "auth.go")
+	if _, err := os.Stat(authConfig); os.IsNotExist(err) {
+		data := struct{ ProjectName string }{projectName}
+		if err := templates.New(".").Generate("config_auth.tmpl", authConfig, data); err != nil {
+			return fmt.Errorf("erro ao gerar %s: %w", authConfig, err)
+		}
+	}
+
+	imports := []string{"net/http", "strings", projectName + "/config/auth"}
+	if err := updateMiddlewares(projectName, authMiddlewares, imports); err != nil {
+		return fmt.Errorf("erro ao atualizar middlewares de autenticação: %w", err)
+	}
+
+	return addAuthEnvVars()
+}
+
+// authMiddlewares são os middlewares de autenticação copiados do template
+// (o Auth de exemplo só checava se o header existia)
+var authMiddlewares = []templateFunc{
+	{Name: "Auth", Marker: "ParseAccessToken"},
+	{Name: "RequireRole", Marker: "auth.CurrentRole"},
+}
+
+// addAuthEnvVars adiciona as variáveis do JWT em .env (com segredo aleatório) e .env.example
+func addAuthEnvVars() error {
+	vars := []config.EnvVar{
+		{Key: "JWT_SECRET", Value: config.GenerateSecret()},
+		{Key: "JWT_ACCESS_TTL", Value: "15m"},
+		{Key: "JWT_REFRESH_TTL", Value: "720h"},
+		{Key: "AUTH_REGISTRATION_ENABLED", Value: "true"},
+	}
+	if _, err := config.AppendEnvVars(".env", "Autenticação (gaver add auth)", vars); err != nil {
+		return err
+	}
+
+	vars[0].Value = ""
+	if _, err := config.AppendEnvVars(".env.example", "Autenticação (gaver add auth)", vars); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/generator"
+	"github.com/Dalistor/gaver/pkg/parser"
+)
+
+// factoryFiles são os arquivos do pacote config/factory
+var factoryFiles = []struct {
+	Template string
+	Output   string
+}{
+	{"config_factory.tmpl", filepath.Join("config", "factory", "factory.go")},
+	{"config_factory_fake.tmpl", filepath.Join("config", "factory", "fake.go")},
+}
+
+// CreateFactory gera a factory do model em modules/<módulo>/factories e as
+// factories ainda inexistentes dos models das relações obrigatórias.
+// Retorna os arquivos gerados.
+func CreateFactory(moduleName, modelName string, force bool) ([]string, error) {
+	projectName, err := getProjectName()
+	if err != nil {
+		return nil, fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	if _, err := os.Stat(filepath.Join("modules", moduleName)); os.IsNotExist(err) {
+		return nil, fmt.Errorf("módulo '%s' não existe", moduleName)
+	}
+	if _, err := os.Stat(modelPath(moduleName, modelName)); os.IsNotExist(err) {
+		return nil, fmt.Errorf("model '%s' não existe no módulo '%s'", modelName, moduleName)
+	}
+
+	path := factoryPath(moduleName, modelName)
+	if _, err := os.Stat(path); err == nil && !force {
+		return nil, fmt.Errorf("a factory de %s já existe em %s (use --force para sobrescrever)", modelName, path)
+	}
+
+	created, err := ensureFactorySupport(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := generator.NewModuleGenerator("templates", projectName)
+	files, err := generateFactory(gen, moduleName, modelName, map[string]bool{})
+	return append(created, files...), err
+}
+
+// generateFactory gera a factory do model e, antes, as das relações
+// obrigatórias que ainda não existem (visited evita gerar duas vezes)
+func generateFactory(gen *generator.ModuleGenerator, moduleName, modelName string, visited map[string]bool) ([]string, error) {
+	visited[moduleName+"."+modelName] = true
+
+	metadata, err := parser.ParseModelFile(modelPath(moduleName, modelName))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao parsear model %s: %w", modelName, err)
+	}
+
+	var created []string
+	var parents []generator.FactoryParent
+	for _, relation := range generator.RequiredRelations(metadata) {
+		parent := generator.FactoryParent{RequiredRelation: relation}
+
+		parentModule := findModelModule(relation.Model, moduleName)
+		if parentModule == "" {
+			fmt.Printf("⚠️  Model %s (relação %s de %s) não encontrado em modules/: preencha a chave com um override\n", relation.Model, relation.Field.Name, modelName)
+			parents = append(parents, parent)
+			continue
+		}
+
+		parentMetadata, err := parser.ParseModelFile(modelPath(parentModule, relation.Model))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao parsear model %s: %w", relation.Model, err)
+		}
+		parent.Module = parentModule
+		parent.IDType = primaryKeyType(parentMetadata)
+		parents = append(parents, parent)
+
+		key := parentModule + "." + relation.Model
+		if visited[key] {
+			continue
+		}
+		if _, err := os.Stat(factoryPath(parentModule, relation.Model)); err == nil {
+			continue
+		}
+		files, err := generateFactory(gen, parentModule, relation.Model, visited)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, files...)
+	}
+
+	path, err := gen.GenerateFactory(moduleName, modelName, metadata, parents)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar factory de %s: %w", modelName, err)
+	}
+	return append(created, path), nil
+}
+
+// ensureFactorySupport gera o pacote config/factory em projetos criados
+// antes dele. Retorna os arquivos criados.
+func ensureFactorySupport(projectName string) ([]string, error) {
+	data := struct{ ProjectName string }{projectName}
+
+	var created []string
+	for _, file := range factoryFiles {
+		if _, err := os.Stat(file.Output); err == nil {
+			continue
+		}
+		if err := templates.New(".").Generate(file.Template, file.Output, data); err != nil {
+			return nil, fmt.Errorf("erro ao gerar %s: %w", file.Output, err)
+		}
+		created = append(created, file.Output)
+	}
+	return created, nil
+}
+
+// findModelModule procura o model em modules/*/models, começando pelo
+// módulo informado ("" se não existir)
+func findModelModule(modelName, preferred string) string {
+	if _, err := os.Stat(modelPath(preferred, modelName)); err == nil {
+		return preferred
+	}
+
+	entries, err := os.ReadDir("modules")
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(modelPath(entry.Name(), modelName)); err == nil {
+			return entry.Name()
+		}
+	}
+	return ""
+}
+
+// primaryKeyType retorna o tipo da chave primária do model
+func primaryKeyType(metadata *parser.ModelMetadata) string {
+	for _, field := range metadata.Fields {
+		if field.PrimaryKey || field.Name == "ID" {
+			return field.Type
+		}
+	}
+	return ""
+}
+
+func modelPath(moduleName, modelName string) string {
+	return filepath.Join("modules", moduleName, "models", generator.ToSnakeCase(modelName)+".go")
+}
+
+func factoryPath(moduleName, modelName string) string {
+	return filepath.Join("modules", moduleName, "factories", generator.ToSnakeCase(modelName)+"_factory.go")
+}
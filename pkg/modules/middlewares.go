@@ -0,0 +1,74 @@
+package modules
+
+import (
+	"path/filepath"
+	"strings"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/editor"
+)
+
+// templateFunc é uma função copiada de um template para projetos existentes.
+// Marker é um trecho que só existe na versão atual.
+type templateFunc struct {
+	Name   string
+	Marker string
+}
+
+// updateMiddlewares troca ou adiciona em config/middlewares os middlewares
+// que ainda não estão na versão do template, adicionando os imports usados
+func updateMiddlewares(projectName string, middlewares []templateFunc, imports []string) error {
+	middlewaresFile := filepath.Join("config", "middlewares", "middlewares.go")
+	return updateFromTemplate(middlewaresFile, "config_middlewares.tmpl", projectName, middlewares, imports)
+}
+
+// updateFromTemplate troca ou adiciona no arquivo gerado as funções que ainda
+// não estão na versão do template, adicionando os imports usados
+func updateFromTemplate(path, templateName, projectName string, funcs []templateFunc, imports []string) error {
+	file, err := editor.Open(path)
+	if err != nil {
+		return err
+	}
+
+	var reference *editor.File
+	changed := false
+
+	for _, fn := range funcs {
+		current, err := file.FuncSource("", fn.Name)
+		if err == nil && strings.Contains(current, fn.Marker) {
+			continue
+		}
+
+		// A versão atual vem do próprio template
+		if reference == nil {
+			rendered, err := templates.Render(templateName, struct{ ProjectName string }{projectName})
+			if err != nil {
+				return err
+			}
+			if reference, err = editor.Parse(templateName, rendered); err != nil {
+				return err
+			}
+		}
+
+		code, err := reference.FuncSource("", fn.Name)
+		if err != nil {
+			return err
+		}
+		if err := file.ReplaceFunc("", fn.Name, code); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	for _, importPath := range imports {
+		if err := file.AddImport(importPath); err != nil {
+			return err
+		}
+	}
+
+	return file.Save()
+}
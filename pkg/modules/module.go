@@ -2,11 +2,14 @@ package modules
 
 import (
 	"fmt"
+	"go/ast"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
 	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/editor"
 	"github.com/Dalistor/gaver/pkg/generator"
 	"github.com/Dalistor/gaver/pkg/parser"
 )
@@ -74,7 +77,7 @@ func CreateModelTemplate(moduleName, modelName string) error {
 	gen := templates.New(filepath.Join("modules", moduleName, "models"))
 
 	// Calcular nome da tabela
-	tableName := toSnakeCase(pluralize(modelName))
+	tableName := parser.Pluralize(generator.ToSnakeCase(modelName))
 
 	data := struct {
 		ModelName string
@@ -84,7 +87,7 @@ func CreateModelTemplate(moduleName, modelName string) error {
 		TableName: tableName,
 	}
 
-	filename := toSnakeCase(modelName) + ".go"
+	filename := generator.ToSnakeCase(modelName) + ".go"
 	return gen.Generate("module_model_template.tmpl", filename, data)
 }
 
@@ -96,7 +99,7 @@ func CreateCRUD(moduleName, modelName string, only, except []string) error {
 	}
 
 	// Verificar se model existe
-	modelFile := filepath.Join("modules", moduleName, "models", toSnakeCase(modelName)+".go")
+	modelFile := filepath.Join("modules", moduleName, "models", generator.ToSnakeCase(modelName)+".go")
 	if _, err := os.Stat(modelFile); os.IsNotExist(err) {
 		return fmt.Errorf("model '%s' não existe no módulo '%s'", modelName, moduleName)
 	}
@@ -107,6 +110,10 @@ func CreateCRUD(moduleName, modelName string, only, except []string) error {
 		return fmt.Errorf("erro ao parsear model: %w", err)
 	}
 
+	if err := metadata.ValidatePermissions(); err != nil {
+		return err
+	}
+
 	// Determinar quais métodos gerar
 	methods := determineMethods(only, except)
 
@@ -115,24 +122,52 @@ func CreateCRUD(moduleName, modelName string, only, except []string) error {
 		return fmt.Errorf("erro ao gerar handler: %w", err)
 	}
 
+	// Handlers validam as annotations com config/validation
+	if err := ensureValidationSupport(); err != nil {
+		return fmt.Errorf("erro ao preparar validação: %w", err)
+	}
+
+	// Gerar testes HTTP do handler
+	if err := generateHandlerTestWithMetadata(moduleName, modelName, metadata, methods); err != nil {
+		return fmt.Errorf("erro ao gerar testes do handler: %w", err)
+	}
+
+	// Repositories usam database.Conn e handlers, middlewares.Canceled
+	if err := ensureContextSupport(); err != nil {
+		return fmt.Errorf("erro ao preparar contexto e transações: %w", err)
+	}
+
+	// Services e repositories abrem spans com config/tracing
+	if err := ensureTracingSupport(); err != nil {
+		return fmt.Errorf("erro ao preparar tracing: %w", err)
+	}
+
 	// Gerar service
-	if err := generateService(moduleName, modelName, methods); err != nil {
+	if err := generateServiceWithMetadata(moduleName, modelName, metadata, methods); err != nil {
 		return fmt.Errorf("erro ao gerar service: %w", err)
 	}
 
 	// Gerar repository
-	if err := generateRepository(moduleName, modelName, methods); err != nil {
+	if err := generateRepositoryWithMetadata(moduleName, modelName, metadata, methods); err != nil {
 		return fmt.Errorf("erro ao gerar repository: %w", err)
 	}
 
+	// Rotas com permissions dependem do middleware Auth e do config/auth
+	if len(metadata.Permissions) > 0 {
+		if err := ensureAuthSupport(); err != nil {
+			return fmt.Errorf("erro ao preparar autenticação: %w", err)
+		}
+	}
+
 	// Atualizar module.go com as rotas
-	if err := updateModuleRoutes(moduleName, modelName, methods); err != nil {
+	if err := updateModuleRoutes(moduleName, modelName, metadata, methods); err != nil {
 		return fmt.Errorf("erro ao atualizar rotas: %w", err)
 	}
 
 	// Registrar módulo em config/modules/modules.go
 	if err := registerModuleInConfig(moduleName); err != nil {
-		fmt.Printf("⚠️  Aviso: Adicione manualmente o módulo em config/modules/modules.go\n")
+		fmt.Printf("⚠️  Aviso: %v\n", err)
+		fmt.Printf("    Adicione manualmente o módulo em config/modules/modules.go\n")
 		fmt.Printf("    registry.Register(\"%s\", %s.NewModule())\n", moduleName, moduleName)
 	}
 
@@ -149,7 +184,7 @@ func parseFields(fields []string) []FieldDef {
 		}
 
 		fieldDef := FieldDef{
-			Name: capitalize(parts[0]),
+			Name: generator.Capitalize(parts[0]),
 			Type: getGoType(parts[1]),
 			Tags: []string{},
 		}
@@ -256,45 +291,170 @@ func determineMethods(only, except []string) map[string]bool {
 	return allMethods
 }
 
-func generateHandler(moduleName, modelName string, methods map[string]bool) error {
-	// Obter nome do projeto atual
+func generateHandlerWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
 	projectName, err := getProjectName()
 	if err != nil {
-		projectName = "gaver-project" // fallback
+		projectName = "gaver-project"
 	}
 
 	gen := generator.NewModuleGenerator("templates", projectName)
-	return gen.GenerateHandler(moduleName, modelName, methods)
+	return gen.GenerateHandlerWithMetadata(moduleName, modelName, metadata, methods)
 }
 
-func generateHandlerWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
+// ensureValidationSupport gera o pacote config/validation em projetos criados
+// antes dele e avisa se o go.mod não tem o driver SQLite usado pelos testes
+func ensureValidationSupport() error {
+	projectName, err := getProjectName()
+	if err != nil {
+		return fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	validationConfig := filepath.Join("config", "validation", "validation.go")
+	if _, err := os.Stat(validationConfig); os.IsNotExist(err) {
+		data := struct{ ProjectName string }{projectName}
+		if err := templates.New(".").Generate("config_validation.tmpl", validationConfig, data); err != nil {
+			return fmt.Errorf("erro ao gerar %s: %w", validationConfig, err)
+		}
+	}
+
+	warnMissingSQLite()
+
+	return nil
+}
+
+// contextMiddlewares são os middlewares de cancelamento usados pelos handlers
+var contextMiddlewares = []templateFunc{
+	{Name: "Canceled", Marker: "StatusClientClosedRequest"},
+}
+
+// ensureContextSupport gera config/database/tx.go (transações no ctx) e o
+// middleware Canceled em projetos criados antes deles
+func ensureContextSupport() error {
+	projectName, err := getProjectName()
+	if err != nil {
+		return fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	txFile := filepath.Join("config", "database", "tx.go")
+	if _, err := os.Stat(txFile); os.IsNotExist(err) {
+		data := struct{ ProjectName string }{projectName}
+		if err := templates.New(".").Generate("config_database_tx.tmpl", txFile, data); err != nil {
+			return fmt.Errorf("erro ao gerar %s: %w", txFile, err)
+		}
+		fmt.Printf("✓ Criado %s\n", txFile)
+	}
+
+	imports := []string{"context", "errors", "net/http"}
+	if err := updateMiddlewares(projectName, contextMiddlewares, imports); err != nil {
+		return fmt.Errorf("erro ao atualizar middlewares: %w", err)
+	}
+
+	// Canceled usa a constante do status 499, declarada fora da função
+	middlewaresFile := filepath.Join("config", "middlewares", "middlewares.go")
+	file, err := editor.Open(middlewaresFile)
+	if err != nil {
+		return err
+	}
+	if src, err := file.Bytes(); err == nil && strings.Contains(string(src), "const StatusClientClosedRequest") {
+		return nil
+	}
+	decl := `// StatusClientClosedRequest é o status registrado quando o cliente desconecta
+// antes da resposta (convenção do nginx)
+const StatusClientClosedRequest = 499`
+	if err := file.AppendDecl(decl); err != nil {
+		return err
+	}
+	return file.Save()
+}
+
+// tracingFiles são os arquivos do pacote config/tracing
+var tracingFiles = []struct {
+	Template string
+	Output   string
+}{
+	{"config_tracing.tmpl", filepath.Join("config", "tracing", "tracing.go")},
+	{"config_tracing_gorm.tmpl", filepath.Join("config", "tracing", "gorm.go")},
+}
+
+// tracingModules são as dependências do OpenTelemetry usadas por config/tracing
+// (mesmas versões do go.mod de projetos novos)
+var tracingModules = []string{
+	"go.opentelemetry.io/otel@v1.38.0",
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp@v1.38.0",
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace@v1.38.0",
+	"go.opentelemetry.io/otel/sdk@v1.38.0",
+	"go.opentelemetry.io/otel/trace@v1.38.0",
+}
+
+// ensureTracingSupport gera o pacote config/tracing em projetos criados antes
+// dele e adiciona as dependências do OpenTelemetry ao go.mod
+func ensureTracingSupport() error {
+	projectName, err := getProjectName()
+	if err != nil {
+		return fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	data := struct{ ProjectName string }{projectName}
+	for _, file := range tracingFiles {
+		if _, err := os.Stat(file.Output); err == nil {
+			continue
+		}
+		if err := templates.New(".").Generate(file.Template, file.Output, data); err != nil {
+			return fmt.Errorf("erro ao gerar %s: %w", file.Output, err)
+		}
+		fmt.Printf("✓ Criado %s\n", file.Output)
+	}
+
+	if goMod, err := os.ReadFile("go.mod"); err != nil || strings.Contains(string(goMod), "go.opentelemetry.io/otel/sdk ") {
+		return nil
+	}
+
+	fmt.Println("Adicionando as dependências do OpenTelemetry ao go.mod...")
+	getCmd := exec.Command("go", append([]string{"get"}, tracingModules...)...)
+	getCmd.Stdout = os.Stdout
+	getCmd.Stderr = os.Stderr
+	if err := getCmd.Run(); err != nil {
+		fmt.Printf("⚠️  Não foi possível adicionar o OpenTelemetry: execute 'go get %s'\n", strings.Join(tracingModules, " "))
+	}
+	return nil
+}
+
+// warnMissingSQLite avisa se o go.mod não tem o driver SQLite usado pelos
+// testes gerados (projetos com outro banco criados antes deles)
+func warnMissingSQLite() {
+	if goMod, err := os.ReadFile("go.mod"); err == nil && !strings.Contains(string(goMod), "github.com/glebarez/sqlite") {
+		fmt.Println("⚠️  Os testes gerados usam github.com/glebarez/sqlite: execute 'go get github.com/glebarez/sqlite && go mod tidy'")
+	}
+}
+
+func generateHandlerTestWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
 	projectName, err := getProjectName()
 	if err != nil {
 		projectName = "gaver-project"
 	}
 
 	gen := generator.NewModuleGenerator("templates", projectName)
-	return gen.GenerateHandlerWithMetadata(moduleName, modelName, metadata, methods)
+	return gen.GenerateHandlerTestWithMetadata(moduleName, modelName, resourcePath(modelName), metadata, methods)
 }
 
-func generateService(moduleName, modelName string, methods map[string]bool) error {
+func generateServiceWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
 	projectName, err := getProjectName()
 	if err != nil {
 		projectName = "gaver-project"
 	}
 
 	gen := generator.NewModuleGenerator("templates", projectName)
-	return gen.GenerateService(moduleName, modelName, methods)
+	return gen.GenerateServiceWithMetadata(moduleName, modelName, metadata, methods)
 }
 
-func generateRepository(moduleName, modelName string, methods map[string]bool) error {
+func generateRepositoryWithMetadata(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
 	projectName, err := getProjectName()
 	if err != nil {
 		projectName = "gaver-project"
 	}
 
 	gen := generator.NewModuleGenerator("templates", projectName)
-	return gen.GenerateRepository(moduleName, modelName, methods)
+	return gen.GenerateRepositoryWithMetadata(moduleName, modelName, metadata, methods)
 }
 
 func getProjectName() (string, error) {
@@ -314,277 +474,124 @@ func getProjectName() (string, error) {
 	return "", fmt.Errorf("nome do projeto não encontrado")
 }
 
-func capitalize(s string) string {
-	if len(s) == 0 {
-		return s
-	}
-	return strings.ToUpper(s[:1]) + s[1:]
-}
-
 // updateModuleRoutes atualiza o arquivo module.go com as rotas do CRUD
-func updateModuleRoutes(moduleName, modelName string, methods map[string]bool) error {
+func updateModuleRoutes(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) error {
 	moduleFile := filepath.Join("modules", moduleName, "module.go")
 
-	// Ler arquivo existente
-	content, err := os.ReadFile(moduleFile)
+	file, err := editor.Open(moduleFile)
 	if err != nil {
 		return err
 	}
 
-	contentStr := string(content)
-
-	// Preparar código das rotas
-	routesCode := generateRoutesCode(moduleName, modelName, methods)
-
-	// Verificar se já existe código de rotas
-	if strings.Contains(contentStr, "RegisterRoutes") {
-		// Adicionar ou substituir rotas do model específico
-		contentStr = replaceOrAddModelRoutes(contentStr, modelName, routesCode)
-	} else {
-		// Adicionar função RegisterRoutes
-		contentStr = addRoutesFunction(contentStr, routesCode)
-	}
-
-	// Salvar arquivo atualizado
-	return os.WriteFile(moduleFile, []byte(contentStr), 0644)
-}
-
-func generateRoutesCode(moduleName, modelName string, methods map[string]bool) string {
-	var code strings.Builder
-
-	modelLower := toLower(modelName)
-	handlerVar := modelLower + "Handler"
-
-	code.WriteString("\t// Inicializar " + modelName + " handler\n")
-	code.WriteString(fmt.Sprintf("\t%sRepo := repositories.New%sRepository()\n", modelLower, modelName))
-	code.WriteString(fmt.Sprintf("\t%sService := services.New%sService(%sRepo)\n", modelLower, modelName, modelLower))
-	code.WriteString(fmt.Sprintf("\t%s := handlers.New%sHandler(%sService)\n\n", handlerVar, modelName, modelLower))
-
-	resourcePath := "/" + toSnakeCase(pluralize(modelName))
-
-	if methods["list"] {
-		code.WriteString(fmt.Sprintf("\trouter.GET(\"%s\", %s.List)\n", resourcePath, handlerVar))
-	}
-	if methods["get"] {
-		code.WriteString(fmt.Sprintf("\trouter.GET(\"%s/:id\", %s.Get)\n", resourcePath, handlerVar))
-	}
-	if methods["create"] {
-		code.WriteString(fmt.Sprintf("\trouter.POST(\"%s\", %s.Create)\n", resourcePath, handlerVar))
-	}
-	if methods["update"] {
-		code.WriteString(fmt.Sprintf("\trouter.PUT(\"%s/:id\", %s.Update)\n", resourcePath, handlerVar))
-	}
-	if methods["patch"] {
-		code.WriteString(fmt.Sprintf("\trouter.PATCH(\"%s/:id\", %s.Patch)\n", resourcePath, handlerVar))
-	}
-	if methods["delete"] {
-		code.WriteString(fmt.Sprintf("\trouter.DELETE(\"%s/:id\", %s.Delete)\n", resourcePath, handlerVar))
+	projectName, err := getProjectName()
+	if err != nil {
+		projectName = "gaver-project"
 	}
 
-	return code.String()
-}
-
-func replaceOrAddModelRoutes(content, modelName, newRoutesCode string) string {
-	// Primeiro, adicionar imports necessários
-	projectName, moduleName := extractModuleInfo(content)
-	content = ensureImport(content, projectName+"/modules/"+moduleName+"/handlers")
-	content = ensureImport(content, projectName+"/modules/"+moduleName+"/services")
-	content = ensureImport(content, projectName+"/modules/"+moduleName+"/repositories")
-
-	// Encontrar função RegisterRoutes
-	startMarker := "func (m *Module) RegisterRoutes(router *gin.RouterGroup) {"
-	startIdx := strings.Index(content, startMarker)
-	if startIdx == -1 {
-		return content
-	}
-
-	// Encontrar o fechamento da função
-	braceCount := 0
-	endIdx := startIdx + len(startMarker)
-
-	for i := endIdx; i < len(content); i++ {
-		if content[i] == '{' {
-			braceCount++
-		} else if content[i] == '}' {
-			if braceCount == 0 {
-				endIdx = i
-				break
-			}
-			braceCount--
+	// Adicionar imports necessários
+	for _, pkg := range []string{"handlers", "services", "repositories"} {
+		if err := file.AddImport(projectName + "/modules/" + moduleName + "/" + pkg); err != nil {
+			return err
 		}
 	}
-
-	// Extrair conteúdo existente da função
-	existingContent := content[startIdx+len(startMarker) : endIdx]
-
-	// Verificar se já existe handler para este model
-	markerComment := "// Inicializar " + modelName + " handler"
-
-	// Se já existe, remover o bloco antigo
-	if strings.Contains(existingContent, markerComment) {
-		existingContent = removeModelBlock(existingContent, markerComment)
-	}
-
-	// Adicionar separador se já houver conteúdo
-	separator := ""
-	if strings.TrimSpace(existingContent) != "" {
-		separator = "\n"
+	if err := file.AddImport(projectName + "/config/database"); err != nil {
+		return err
 	}
 
-	// Reconstruir conteúdo
-	newContent := content[:startIdx+len(startMarker)] + existingContent + separator + newRoutesCode + "\n" + content[endIdx:]
-	return newContent
-}
+	// Preparar código das rotas
+	routesCode := generateRoutesCode(moduleName, modelName, metadata, methods)
 
-// removeModelBlock remove o bloco de código de um model específico
-func removeModelBlock(content, markerComment string) string {
-	lines := strings.Split(content, "\n")
-	var result []string
-	skip := false
+	middlewaresImport := projectName + "/config/middlewares"
+	if err := file.AddImport(middlewaresImport); err != nil {
+		return err
+	}
 
-	for _, line := range lines {
-		if strings.Contains(line, markerComment) {
-			skip = true
-			continue
+	if file.FindFunc("Module", "RegisterRoutes") == nil {
+		// Adicionar função RegisterRoutes
+		if err := file.AddImport("github.com/gin-gonic/gin"); err != nil {
+			return err
 		}
-
-		// Detectar fim do bloco (linha vazia ou outro comentário de inicialização)
-		if skip {
-			trimmed := strings.TrimSpace(line)
-			if trimmed == "" || strings.HasPrefix(trimmed, "// Inicializar") {
-				skip = false
-			} else {
-				continue // Pular linhas do bloco
-			}
+		routesFunc := fmt.Sprintf(`// RegisterRoutes registra as rotas do módulo
+func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
+%s}`, routesCode)
+		if err := file.AppendDecl(routesFunc); err != nil {
+			return err
+		}
+		if err := file.RemoveImportIfUnused(middlewaresImport); err != nil {
+			return err
 		}
+		return file.Save()
+	}
 
-		result = append(result, line)
+	// Substituir o bloco do model, se já existir
+	if _, err := file.RemoveStmts("Module", "RegisterRoutes", isModelRoutesStmt(modelName)); err != nil {
+		return err
 	}
 
-	return strings.Join(result, "\n")
-}
+	if err := file.AppendStmts("Module", "RegisterRoutes", routesCode); err != nil {
+		return err
+	}
 
-func addRoutesFunction(content, routesCode string) string {
-	// Extrair nome do módulo e projeto
-	projectName, moduleName := extractModuleInfo(content)
-
-	// Adicionar imports necessários se não existirem
-	content = ensureImport(content, "github.com/gin-gonic/gin")
-	content = ensureImport(content, projectName+"/modules/"+moduleName+"/handlers")
-	content = ensureImport(content, projectName+"/modules/"+moduleName+"/services")
-	content = ensureImport(content, projectName+"/modules/"+moduleName+"/repositories")
-
-	// Adicionar função RegisterRoutes antes do último }
-	lastBrace := strings.LastIndex(content, "}")
-	if lastBrace != -1 {
-		routesFunc := fmt.Sprintf(`
-// RegisterRoutes registra as rotas do módulo
-func (m *Module) RegisterRoutes(router *gin.RouterGroup) {
-%s
-}
-`, routesCode)
-		content = content[:lastBrace] + routesFunc + "\n" + content[lastBrace:]
+	// Sem permissions o import de middlewares pode não ser usado
+	if err := file.RemoveImportIfUnused(middlewaresImport); err != nil {
+		return err
 	}
 
-	return content
+	return file.Save()
 }
 
-func extractModuleInfo(content string) (projectName, moduleName string) {
-	// Extrair package name (nome do módulo)
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "package ") {
-			moduleName = strings.TrimSpace(strings.TrimPrefix(line, "package "))
-			break
-		}
-	}
+// isModelRoutesStmt reconhece os statements do bloco "// Inicializar X handler"
+// pelas variáveis que ele declara e usa
+func isModelRoutesStmt(modelName string) func(stmt ast.Stmt) bool {
+	modelLower := generator.ToLower(modelName)
+	vars := []string{modelLower + "Repo", modelLower + "Service", modelLower + "Handler"}
 
-	// Extrair project name do go.mod
-	projectName, _ = getProjectName()
-	if projectName == "" {
-		projectName = "gaver-project"
+	return func(stmt ast.Stmt) bool {
+		return editor.ReferencesAny(stmt, vars...)
 	}
-
-	return projectName, moduleName
 }
 
-func ensureImport(content, importPath string) string {
-	// Se já tem o import não comentado, retorna sem modificar
-	checkImport := "\"" + importPath + "\""
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.Contains(trimmed, checkImport) && !strings.HasPrefix(trimmed, "//") {
-			return content
-		}
-	}
+func generateRoutesCode(moduleName, modelName string, metadata *parser.ModelMetadata, methods map[string]bool) string {
+	var code strings.Builder
 
-	// Encontrar bloco de imports
-	importIdx := strings.Index(content, "import (")
-	if importIdx == -1 {
-		return content
-	}
+	modelLower := generator.ToLower(modelName)
+	handlerVar := modelLower + "Handler"
 
-	// Encontrar o fim do bloco de imports
-	closeParenIdx := strings.Index(content[importIdx:], ")")
-	if closeParenIdx == -1 {
-		return content
-	}
-	closeParenIdx += importIdx
+	code.WriteString("\t// Inicializar " + modelName + " handler\n")
+	code.WriteString(fmt.Sprintf("\t%sRepo := repositories.New%sRepository(database.DB)\n", modelLower, modelName))
+	code.WriteString(fmt.Sprintf("\t%sService := services.New%sService(%sRepo)\n", modelLower, modelName, modelLower))
+	code.WriteString(fmt.Sprintf("\t%s := handlers.New%sHandler(%sService)\n\n", handlerVar, modelName, modelLower))
 
-	// Inserir antes do )
-	newImport := "\t\"" + importPath + "\"\n"
-	content = content[:closeParenIdx] + newImport + content[closeParenIdx:]
+	resourcePath := resourcePath(modelName)
 
-	return content
-}
+	for _, route := range generator.CRUDRoutes(resourcePath) {
+		if !methods[route.Action] {
+			continue
+		}
 
-func pluralize(s string) string {
-	s = strings.ToLower(s)
-	if strings.HasSuffix(s, "s") {
-		return s
-	}
-	if strings.HasSuffix(s, "y") {
-		return s[:len(s)-1] + "ies"
-	}
-	if strings.HasSuffix(s, "ch") || strings.HasSuffix(s, "sh") || strings.HasSuffix(s, "x") {
-		return s + "es"
+		args := []string{fmt.Sprintf("%q", route.Path)}
+		args = append(args, generator.RouteMiddlewares(metadata.PermissionFor(route.Action))...)
+		args = append(args, handlerVar+"."+route.Handler)
+		code.WriteString(fmt.Sprintf("\trouter.%s(%s)\n", route.Method, strings.Join(args, ", ")))
 	}
-	return s + "s"
-}
 
-// toLower converte primeira letra para minúscula
-func toLower(s string) string {
-	if len(s) == 0 {
-		return s
-	}
-	return strings.ToLower(s[:1]) + s[1:]
+	return code.String()
 }
 
-// toSnakeCase converte CamelCase para snake_case
-func toSnakeCase(s string) string {
-	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteRune('_')
-		}
-		result.WriteRune(r)
-	}
-	return strings.ToLower(result.String())
+// resourcePath retorna o caminho das rotas de CRUD do model (Product -> /products)
+func resourcePath(modelName string) string {
+	return "/" + parser.Pluralize(generator.ToSnakeCase(modelName))
 }
 
 // registerModuleInConfig adiciona o módulo em config/modules/modules.go
 func registerModuleInConfig(moduleName string) error {
 	configFile := filepath.Join("config", "modules", "modules.go")
 
-	// Ler arquivo existente
-	content, err := os.ReadFile(configFile)
+	file, err := editor.Open(configFile)
 	if err != nil {
 		return err
 	}
 
-	contentStr := string(content)
-
 	// Obter nome do projeto
 	projectName, err := getProjectName()
 	if err != nil {
@@ -592,42 +599,28 @@ func registerModuleInConfig(moduleName string) error {
 	}
 
 	// Adicionar import do módulo
-	moduleImport := projectName + "/modules/" + moduleName
-	contentStr = ensureImport(contentStr, moduleImport)
-
-	// Adicionar registro do módulo na função RegisterModules
-	registerLine := fmt.Sprintf("\tregistry.Register(\"%s\", %s.NewModule())\n", moduleName, moduleName)
-
-	// Procurar pela função RegisterModules
-	marker := "func RegisterModules(registry *routes.Registry) {"
-	markerIdx := strings.Index(contentStr, marker)
-
-	if markerIdx != -1 {
-		// Encontrar onde inserir (depois do comentário inicial)
-		insertPoint := markerIdx + len(marker)
-
-		// Pular linhas de comentário
-		lines := strings.Split(contentStr[insertPoint:], "\n")
-		skipLines := 0
-		for i, line := range lines {
-			trimmed := strings.TrimSpace(line)
-			if trimmed == "" || strings.HasPrefix(trimmed, "//") {
-				skipLines = i + 1
-				continue
-			}
-			break
-		}
+	if err := file.AddImport(projectName + "/modules/" + moduleName); err != nil {
+		return err
+	}
 
-		// Inserir após os comentários
-		insertPoint += len(strings.Join(lines[:skipLines], "\n"))
-		if skipLines > 0 {
-			insertPoint += 1 // newline
-		}
+	// Adicionar registro do módulo na função RegisterModules (uma única vez)
+	registered, err := file.HasStmt("", "RegisterModules", func(stmt ast.Stmt) bool {
+		return editor.CallsMethod(stmt, "", "Register", moduleName)
+	})
+	if err != nil {
+		return err
+	}
 
-		actualInsertPoint := markerIdx + len(marker) + insertPoint - markerIdx - len(marker)
-		contentStr = contentStr[:actualInsertPoint] + "\n" + registerLine + contentStr[actualInsertPoint:]
+	if !registered {
+		registry, err := file.ParamName("", "RegisterModules", 0)
+		if err != nil {
+			return err
+		}
+		registerLine := fmt.Sprintf("%s.Register(%q, %s.NewModule())", registry, moduleName, moduleName)
+		if err := file.AppendStmts("", "RegisterModules", registerLine); err != nil {
+			return err
+		}
 	}
 
-	// Salvar arquivo atualizado
-	return os.WriteFile(configFile, []byte(contentStr), 0644)
+	return file.Save()
 }
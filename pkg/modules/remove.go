@@ -0,0 +1,326 @@
+package modules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Dalistor/gaver/pkg/editor"
+	"github.com/Dalistor/gaver/pkg/generator"
+)
+
+// RemoveModule remove a pasta do módulo e o seu registro em config/modules/modules.go
+func RemoveModule(moduleName string) error {
+	basePath := filepath.Join("modules", moduleName)
+
+	// Verificar se módulo existe
+	if _, err := os.Stat(basePath); os.IsNotExist(err) {
+		return fmt.Errorf("módulo '%s' não existe", moduleName)
+	}
+
+	// Desregistrar antes de apagar, para não deixar imports quebrados
+	if err := unregisterModuleInConfig(moduleName); err != nil {
+		return fmt.Errorf("erro ao desregistrar módulo: %w", err)
+	}
+	if err := removeFactorySeeders(moduleName, ""); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(basePath); err != nil {
+		return fmt.Errorf("erro ao remover %s: %w", basePath, err)
+	}
+
+	return nil
+}
+
+// RemoveModel remove o model e, se existirem, o CRUD, a factory e os
+// seeders gerados para ele
+func RemoveModel(moduleName, modelName string) error {
+	modelFile := modelPath(moduleName, modelName)
+	if _, err := os.Stat(modelFile); os.IsNotExist(err) {
+		return fmt.Errorf("model '%s' não existe no módulo '%s'", modelName, moduleName)
+	}
+
+	if err := RemoveCRUD(moduleName, modelName); err != nil {
+		return err
+	}
+
+	// Seeders antes da factory: seeders/ continua compilando se a remoção
+	// falhar no meio do caminho
+	if err := removeFactorySeeders(moduleName, modelName); err != nil {
+		return err
+	}
+	factory := factoryPath(moduleName, modelName)
+	if err := os.Remove(factory); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erro ao remover %s: %w", factory, err)
+	}
+	if err := removeUnusedFactories(filepath.Join("modules", moduleName)); err != nil {
+		return err
+	}
+
+	return os.Remove(modelFile)
+}
+
+// RemoveCRUD remove handler, service, repository e rotas geradas para um model
+func RemoveCRUD(moduleName, modelName string) error {
+	basePath := filepath.Join("modules", moduleName)
+
+	// Verificar se módulo existe
+	if _, err := os.Stat(basePath); os.IsNotExist(err) {
+		return fmt.Errorf("módulo '%s' não existe", moduleName)
+	}
+
+	// Remover rotas primeiro: module.go continua compilando mesmo se a
+	// remoção dos arquivos falhar no meio do caminho
+	if err := removeModuleRoutes(moduleName, modelName); err != nil {
+		return fmt.Errorf("erro ao remover rotas: %w", err)
+	}
+
+	for _, file := range CRUDFiles(moduleName, modelName) {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("erro ao remover %s: %w", file, err)
+		}
+	}
+
+	if err := removeUnusedMocks(basePath); err != nil {
+		return err
+	}
+	return removeUnusedTestHelpers(basePath)
+}
+
+// removeUnusedMocks apaga repositories/mocks quando só resta o store.go,
+// compartilhado pelos mocks dos models
+func removeUnusedMocks(basePath string) error {
+	mocksPath := filepath.Join(basePath, "repositories", "mocks")
+
+	entries, err := os.ReadDir(mocksPath)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.Name() != "store.go" {
+			return nil
+		}
+	}
+
+	if err := os.RemoveAll(mocksPath); err != nil {
+		return fmt.Errorf("erro ao remover %s: %w", mocksPath, err)
+	}
+	return nil
+}
+
+// removeUnusedFactories apaga factories/ quando não resta nenhuma factory
+func removeUnusedFactories(basePath string) error {
+	factoriesPath := filepath.Join(basePath, "factories")
+
+	entries, err := os.ReadDir(factoriesPath)
+	if err != nil || len(entries) > 0 {
+		return nil
+	}
+
+	if err := os.Remove(factoriesPath); err != nil {
+		return fmt.Errorf("erro ao remover %s: %w", factoriesPath, err)
+	}
+	return nil
+}
+
+// FactorySeeders retorna os seeders (seeders/*.go) que usam a factory do
+// model, ou qualquer factory do módulo se modelName for vazio
+func FactorySeeders(moduleName, modelName string) []string {
+	projectName, err := getProjectName()
+	if err != nil {
+		return nil
+	}
+	importPath := projectName + "/modules/" + moduleName + "/factories"
+
+	paths, err := filepath.Glob(filepath.Join("seeders", "*.go"))
+	if err != nil {
+		return nil
+	}
+
+	var seeders []string
+	for _, path := range paths {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			continue
+		}
+		if usesFactory(file, importPath, modelName) {
+			seeders = append(seeders, path)
+		}
+	}
+	return seeders
+}
+
+// usesFactory verifica se o arquivo importa o pacote de factories e, com
+// modelName, se usa a factory do model (<alias>.<Model>)
+func usesFactory(file *ast.File, importPath, modelName string) bool {
+	alias := ""
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err != nil || path != importPath {
+			continue
+		}
+		alias = "factories"
+		if spec.Name != nil {
+			alias = spec.Name.Name
+		}
+	}
+	if alias == "" || modelName == "" {
+		return alias != ""
+	}
+
+	found := false
+	ast.Inspect(file, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == alias && sel.Sel.Name == modelName {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// removeFactorySeeders apaga os seeders que usam as factories removidas e
+// os tira de RegisterDefaultSeeders
+func removeFactorySeeders(moduleName, modelName string) error {
+	seeders := FactorySeeders(moduleName, modelName)
+	if len(seeders) == 0 {
+		return nil
+	}
+
+	var funcs []string
+	for _, path := range seeders {
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("erro ao ler %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				funcs = append(funcs, fn.Name.Name)
+			}
+		}
+	}
+
+	// Desregistrar antes de apagar, para não deixar referências quebradas
+	if _, err := os.Stat(seedersFile); err == nil {
+		file, err := editor.Open(seedersFile)
+		if err != nil {
+			return err
+		}
+		removed, err := file.RemoveStmts("", "RegisterDefaultSeeders", func(stmt ast.Stmt) bool {
+			return editor.CallsMethod(stmt, "", "Register", "") && editor.ReferencesAny(stmt, funcs...)
+		})
+		if err != nil {
+			return err
+		}
+		if removed > 0 {
+			if err := file.Save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, path := range seeders {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("erro ao remover %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// removeUnusedTestHelpers apaga handlers/helpers_test.go quando não resta
+// nenhum teste de handler gerado para usá-lo
+func removeUnusedTestHelpers(basePath string) error {
+	tests, err := filepath.Glob(filepath.Join(basePath, "handlers", "*_handler_test.go"))
+	if err != nil || len(tests) > 0 {
+		return nil
+	}
+
+	helpers := filepath.Join(basePath, "handlers", "helpers_test.go")
+	if err := os.Remove(helpers); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erro ao remover %s: %w", helpers, err)
+	}
+	return nil
+}
+
+// CRUDFiles retorna os arquivos gerados por 'gaver module crud' para um model
+func CRUDFiles(moduleName, modelName string) []string {
+	basePath := filepath.Join("modules", moduleName)
+	snake := generator.ToSnakeCase(modelName)
+
+	return []string{
+		filepath.Join(basePath, "handlers", snake+"_handler.go"),
+		filepath.Join(basePath, "handlers", snake+"_handler_test.go"),
+		filepath.Join(basePath, "services", snake+"_service.go"),
+		filepath.Join(basePath, "repositories", snake+"_repository.go"),
+		filepath.Join(basePath, "repositories", "mocks", snake+"_repository.go"),
+	}
+}
+
+// removeModuleRoutes remove o bloco "// Inicializar X handler" de RegisterRoutes
+func removeModuleRoutes(moduleName, modelName string) error {
+	moduleFile := filepath.Join("modules", moduleName, "module.go")
+
+	file, err := editor.Open(moduleFile)
+	if err != nil {
+		return err
+	}
+
+	removed, err := file.RemoveStmts("Module", "RegisterRoutes", isModelRoutesStmt(modelName))
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return nil
+	}
+
+	// Imports dos pacotes do módulo que ficaram sem uso
+	projectName, err := getProjectName()
+	if err != nil {
+		projectName = "gaver-project"
+	}
+	for _, pkg := range []string{"handlers", "services", "repositories"} {
+		if err := file.RemoveImportIfUnused(projectName + "/modules/" + moduleName + "/" + pkg); err != nil {
+			return err
+		}
+	}
+	for _, pkg := range []string{"config/database", "config/middlewares"} {
+		if err := file.RemoveImportIfUnused(projectName + "/" + pkg); err != nil {
+			return err
+		}
+	}
+
+	return file.Save()
+}
+
+// unregisterModuleInConfig remove o import e o registry.Register do módulo
+func unregisterModuleInConfig(moduleName string) error {
+	configFile := filepath.Join("config", "modules", "modules.go")
+
+	file, err := editor.Open(configFile)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.RemoveStmts("", "RegisterModules", func(stmt ast.Stmt) bool {
+		return editor.CallsMethod(stmt, "", "Register", moduleName)
+	})
+	if err != nil {
+		return err
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	if err := file.RemoveImport(projectName + "/modules/" + moduleName); err != nil {
+		return err
+	}
+
+	return file.Save()
+}
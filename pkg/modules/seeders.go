@@ -0,0 +1,154 @@
+package modules
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	"github.com/Dalistor/gaver/pkg/editor"
+	"github.com/Dalistor/gaver/pkg/generator"
+)
+
+// seedersFile é o arquivo com a ordem de execução dos seeders
+var seedersFile = filepath.Join("seeders", "seeders.go")
+
+// seederFiles são a infraestrutura dos seeders e o comando cmd/seed
+var seederFiles = []struct {
+	Template string
+	Output   string
+}{
+	{"seeders_runner.tmpl", filepath.Join("seeders", "runner.go")},
+	{"seeders.tmpl", seedersFile},
+	{"seed_main.tmpl", filepath.Join("cmd", "seed", "main.go")},
+}
+
+var seederNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// SeederOptions configura o seeder gerado
+type SeederOptions struct {
+	Module string // módulo do model populado pela factory ("" = seeder vazio)
+	Model  string
+	Count  int
+}
+
+// CreateSeeder cria seeders/<nome>.go e o registra ao final de
+// RegisterDefaultSeeders. Com Model, o seeder cria Count registros com a
+// factory do model, gerada se ainda não existir. Retorna os arquivos criados.
+func CreateSeeder(name string, opts SeederOptions) ([]string, error) {
+	if !seederNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("nome inválido: %s (use letras minúsculas, números e _, ex: products)", name)
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return nil, fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	path := filepath.Join("seeders", name+".go")
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("o seeder %s já existe em %s", name, path)
+	}
+
+	created, err := EnsureSeederSupport()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := editor.Open(seedersFile)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir %s: %w", seedersFile, err)
+	}
+	recv, err := file.ParamName("", "RegisterDefaultSeeders", 0)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := file.HasStmt("", "RegisterDefaultSeeders", func(stmt ast.Stmt) bool {
+		return editor.CallsMethod(stmt, recv, "Register", name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("o seeder %s já está registrado em %s", name, seedersFile)
+	}
+
+	if opts.Model != "" {
+		if _, err := os.Stat(factoryPath(opts.Module, opts.Model)); os.IsNotExist(err) {
+			files, err := CreateFactory(opts.Module, opts.Model, false)
+			if err != nil {
+				return nil, err
+			}
+			created = append(created, files...)
+		}
+	}
+
+	data := struct {
+		ProjectName string
+		Name        string
+		FuncName    string
+		Module      string
+		Model       string
+		Alias       string
+		Count       int
+	}{
+		ProjectName: projectName,
+		Name:        name,
+		FuncName:    "Seed" + camelCase(name),
+		Module:      opts.Module,
+		Model:       opts.Model,
+		Alias:       opts.Module + "factories",
+		Count:       opts.Count,
+	}
+	if err := templates.New(".").Generate("seeder.tmpl", path, data); err != nil {
+		return nil, fmt.Errorf("erro ao gerar %s: %w", path, err)
+	}
+
+	code := fmt.Sprintf("%s.Register(%q, %s)", recv, name, data.FuncName)
+	if err := file.AppendStmts("", "RegisterDefaultSeeders", code); err != nil {
+		return nil, err
+	}
+	if err := file.Save(); err != nil {
+		return nil, err
+	}
+
+	return append(created, path), nil
+}
+
+// EnsureSeederSupport gera seeders/ e cmd/seed em projetos criados antes
+// deles (e o config/factory usado pelos seeders). Retorna os arquivos criados.
+func EnsureSeederSupport() ([]string, error) {
+	projectName, err := getProjectName()
+	if err != nil {
+		return nil, fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	created, err := ensureFactorySupport(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct{ ProjectName string }{projectName}
+	for _, file := range seederFiles {
+		if _, err := os.Stat(file.Output); err == nil {
+			continue
+		}
+		if err := templates.New(".").Generate(file.Template, file.Output, data); err != nil {
+			return nil, fmt.Errorf("erro ao gerar %s: %w", file.Output, err)
+		}
+		created = append(created, file.Output)
+	}
+	return created, nil
+}
+
+// camelCase converte snake_case em CamelCase (daily_reports -> DailyReports)
+func camelCase(s string) string {
+	var result strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		result.WriteString(generator.Capitalize(part))
+	}
+	return result.String()
+}
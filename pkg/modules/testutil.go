@@ -0,0 +1,64 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+)
+
+// testUtilFiles mapeia os templates do harness de testes para os arquivos gerados
+var testUtilFiles = []struct {
+	Template string
+	Output   string
+}{
+	{"config_app.tmpl", filepath.Join("config", "app", "app.go")},
+	{"config_testutil.tmpl", filepath.Join("config", "testutil", "testutil.go")},
+	{"config_testutil_client.tmpl", filepath.Join("config", "testutil", "client.go")},
+	{"config_testutil_response.tmpl", filepath.Join("config", "testutil", "response.go")},
+	{"tests_main.tmpl", filepath.Join("tests", "main_test.go")},
+}
+
+// migrationFuncs são as funções do runner de migrations usadas pelo harness
+// (projetos antigos executavam o arquivo inteiro, incluindo o DOWN)
+var migrationFuncs = []templateFunc{
+	{Name: "UpSQL", Marker: "DOWN"},
+	{Name: "executeMigration", Marker: "UpSQL("},
+}
+
+// AddTestUtil gera config/app, o pacote config/testutil e tests/main_test.go
+// em projetos criados antes deles. Retorna os arquivos criados.
+func AddTestUtil() ([]string, error) {
+	projectName, err := getProjectName()
+	if err != nil {
+		return nil, fmt.Errorf("execute o comando na raiz do projeto: %w", err)
+	}
+
+	data := struct{ ProjectName string }{projectName}
+
+	var created []string
+	for _, file := range testUtilFiles {
+		if _, err := os.Stat(file.Output); err == nil {
+			continue
+		}
+		if err := templates.New(".").Generate(file.Template, file.Output, data); err != nil {
+			return nil, fmt.Errorf("erro ao gerar %s: %w", file.Output, err)
+		}
+		created = append(created, file.Output)
+	}
+
+	migrationsFile := filepath.Join("config", "database", "migrations", "migrations.go")
+	if err := updateFromTemplate(migrationsFile, "migration_table.tmpl", projectName, migrationFuncs, []string{"strings"}); err != nil {
+		return nil, fmt.Errorf("erro ao atualizar %s: %w", migrationsFile, err)
+	}
+
+	// AsUser assina tokens com config/auth
+	if err := ensureAuthSupport(); err != nil {
+		return nil, fmt.Errorf("erro ao preparar autenticação: %w", err)
+	}
+
+	warnMissingSQLite()
+
+	return created, nil
+}
@@ -0,0 +1,524 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dalistor/gaver/pkg/generator"
+	gaverParser "github.com/Dalistor/gaver/pkg/parser"
+)
+
+// Builder monta o documento OpenAPI a partir dos módulos do projeto
+type Builder struct {
+	Title    string
+	Version  string
+	BasePath string
+}
+
+// NewBuilder cria um novo builder com o prefixo padrão das rotas (/api/v1)
+func NewBuilder(title, version string) *Builder {
+	return &Builder{
+		Title:    title,
+		Version:  version,
+		BasePath: "/api/v1",
+	}
+}
+
+// writeMethods mapeia a action do handler para o método usado nas annotations writable
+var writeMethods = map[string]string{
+	"Create": "POST",
+	"Update": "PUT",
+	"Patch":  "PATCH",
+}
+
+// writeSuffixes é o sufixo do schema de entrada de cada método
+var writeSuffixes = map[string]string{
+	"POST":  "Create",
+	"PUT":   "Update",
+	"PATCH": "Patch",
+}
+
+var pathParamRegex = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Build gera o documento para os módulos informados
+func (b *Builder) Build(modules []*Module) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: b.Title, Version: b.Version},
+		Servers: []Server{{URL: b.BasePath}},
+		Paths:   map[string]map[string]*Operation{},
+		Components: Components{
+			Schemas: map[string]*Schema{
+				"Error": {
+					Type:       "object",
+					Properties: map[string]*Schema{"error": {Type: "string"}},
+					Required:   []string{"error"},
+				},
+			},
+		},
+	}
+
+	// Nome de schema de cada model (prefixado com o módulo em caso de conflito)
+	names := SchemaNames(modules)
+	operationIDs := map[string]int{}
+
+	for _, module := range modules {
+		doc.Tags = append(doc.Tags, Tag{Name: module.Name})
+
+		for _, model := range module.Models {
+			doc.Components.Schemas[names[module.Name+"."+model.Name]] = readSchema(model, module.Name, names)
+		}
+
+		for _, route := range module.Routes {
+			model := module.Models[route.Model]
+			op := b.operation(route, model, names[module.Name+"."+route.Model], doc)
+			if route.Auth || route.APIKey {
+				secure(op, route, doc)
+			}
+
+			// operationId precisa ser único no documento
+			operationIDs[op.OperationID]++
+			if n := operationIDs[op.OperationID]; n > 1 {
+				op.OperationID += strconv.Itoa(n)
+			}
+
+			path := pathParamRegex.ReplaceAllString(route.Path, "{$1}")
+			if doc.Paths[path] == nil {
+				doc.Paths[path] = map[string]*Operation{}
+			}
+			doc.Paths[path][strings.ToLower(route.Method)] = op
+		}
+	}
+
+	return doc
+}
+
+// SchemaNames define o nome de schema de cada model ("modulo.Model" -> "Model").
+// Models com o mesmo nome em módulos diferentes são prefixados com o módulo.
+func SchemaNames(modules []*Module) map[string]string {
+	count := map[string]int{}
+	for _, module := range modules {
+		for name := range module.Models {
+			count[name]++
+		}
+	}
+
+	names := map[string]string{}
+	for _, module := range modules {
+		for name := range module.Models {
+			if count[name] > 1 {
+				names[module.Name+"."+name] = generator.Capitalize(module.Name) + name
+			} else {
+				names[module.Name+"."+name] = name
+			}
+		}
+	}
+	return names
+}
+
+// operation monta a operação de uma rota
+func (b *Builder) operation(route Route, model *gaverParser.ModelMetadata, schemaName string, doc *Document) *Operation {
+	op := &Operation{
+		Tags:        []string{route.Module},
+		OperationID: generator.ToLower(route.Action) + route.Model,
+		Responses:   map[string]*Response{},
+	}
+	if route.Action == "" {
+		op.OperationID = strings.ToLower(route.Method) + toOperationName(route.Path)
+	}
+
+	op.Parameters = pathParameters(route.Path, model)
+
+	// Handlers do módulo auth (gaver auth): status próprios, sem model
+	if model == nil && route.Model == "Auth" {
+		if responses, ok := authResponses[route.Action]; ok {
+			op.Summary = authSummaries[route.Action]
+			respond(op, responses)
+			return op
+		}
+	}
+
+	// Rotas que não vêm de um handler gerado: documentação genérica
+	if model == nil {
+		op.Responses["200"] = &Response{Description: "Sucesso"}
+		return op
+	}
+
+	responses, ok := crudResponses[route.Action]
+	if !ok {
+		op.Responses["200"] = &Response{Description: "Sucesso"}
+		return op
+	}
+	success := respond(op, responses)
+
+	switch route.Action {
+	case "List":
+		op.Summary = fmt.Sprintf("Lista %s", route.Model)
+		op.Parameters = append(op.Parameters, listParameters(model)...)
+		success.Headers = map[string]Header{
+			"X-Total-Count": {Description: "Total de registros sem paginação", Schema: &Schema{Type: "integer"}},
+		}
+		success.Content = jsonContent(&Schema{Type: "array", Items: ref(schemaName)})
+
+	case "Get":
+		op.Summary = fmt.Sprintf("Busca %s por ID", route.Model)
+		success.Content = jsonContent(ref(schemaName))
+
+	case "Create", "Update", "Patch":
+		method := writeMethods[route.Action]
+		inputName := schemaName + writeSuffixes[method]
+		doc.Components.Schemas[inputName] = writeSchema(model, method)
+
+		op.Summary = map[string]string{
+			"Create": "Cria %s",
+			"Update": "Atualiza %s",
+			"Patch":  "Atualiza parcialmente %s",
+		}[route.Action]
+		op.Summary = fmt.Sprintf(op.Summary, route.Model)
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(ref(inputName))}
+		success.Content = jsonContent(ref(schemaName))
+
+	case "Delete":
+		op.Summary = fmt.Sprintf("Remove %s", route.Model)
+	}
+
+	return op
+}
+
+// statusResponse é um status que o handler responde
+type statusResponse struct {
+	Status      int
+	Description string
+}
+
+// crudResponses são os status de cada action de module_handler.tmpl, na
+// ordem: sucesso primeiro. builder_test.go confere a tabela com o template.
+var crudResponses = map[string][]statusResponse{
+	"List": {
+		{http.StatusOK, "Lista de registros"},
+		{http.StatusBadRequest, "Parâmetros inválidos"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Get": {
+		{http.StatusOK, "Registro encontrado"},
+		{http.StatusBadRequest, "Requisição inválida"},
+		{http.StatusNotFound, "Não encontrado"},
+	},
+	"Create": {
+		{http.StatusCreated, "Registro criado"},
+		{http.StatusBadRequest, "Dados inválidos"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Update": {
+		{http.StatusOK, "Registro salvo"},
+		{http.StatusBadRequest, "Dados inválidos"},
+		{http.StatusNotFound, "Não encontrado"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Patch": {
+		{http.StatusOK, "Registro salvo"},
+		{http.StatusBadRequest, "Dados inválidos"},
+		{http.StatusNotFound, "Não encontrado"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Delete": {
+		{http.StatusNoContent, "Registro removido"},
+		{http.StatusBadRequest, "Requisição inválida"},
+		{http.StatusNotFound, "Não encontrado"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+}
+
+// authResponses são os status de cada action de auth_handler.tmpl
+var authResponses = map[string][]statusResponse{
+	"Register": {
+		{http.StatusCreated, "Usuário cadastrado, com os tokens"},
+		{http.StatusBadRequest, "Dados inválidos"},
+		{http.StatusForbidden, "Cadastro desativado"},
+		{http.StatusConflict, "Email já cadastrado"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Login": {
+		{http.StatusOK, "Usuário e tokens"},
+		{http.StatusBadRequest, "Dados inválidos"},
+		{http.StatusUnauthorized, "Credenciais inválidas"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Refresh": {
+		{http.StatusOK, "Novo par de tokens"},
+		{http.StatusBadRequest, "Dados inválidos"},
+		{http.StatusUnauthorized, "Refresh token inválido"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Logout": {
+		{http.StatusNoContent, "Sessão encerrada"},
+		{http.StatusBadRequest, "Dados inválidos"},
+		{http.StatusInternalServerError, "Erro interno"},
+	},
+	"Me": {
+		{http.StatusOK, "Usuário autenticado"},
+		{http.StatusNotFound, "Usuário não encontrado"},
+	},
+}
+
+var authSummaries = map[string]string{
+	"Register": "Cadastra um usuário",
+	"Login":    "Autentica com email e senha",
+	"Refresh":  "Renova os tokens",
+	"Logout":   "Revoga o refresh token",
+	"Me":       "Retorna o usuário autenticado",
+}
+
+// respond adiciona as respostas da tabela à operação e retorna a de sucesso
+// (a primeira), para receber o schema do corpo
+func respond(op *Operation, responses []statusResponse) *Response {
+	var success *Response
+	for _, r := range responses {
+		code := strconv.Itoa(r.Status)
+		if r.Status >= http.StatusBadRequest {
+			op.Responses[code] = errorResponse(r.Description)
+			continue
+		}
+		op.Responses[code] = &Response{Description: r.Description}
+		if success == nil {
+			success = op.Responses[code]
+		}
+	}
+	return success
+}
+
+// secureSchemes são os esquemas de segurança dos middlewares Auth e APIKeyAuth
+var secureSchemes = map[string]*SecurityScheme{
+	"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+	"apiKeyAuth": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+}
+
+// secure marca a operação como autenticada por bearer token (JWT) e/ou API key
+func secure(op *Operation, route Route, doc *Document) {
+	if doc.Components.SecuritySchemes == nil {
+		doc.Components.SecuritySchemes = map[string]*SecurityScheme{}
+	}
+
+	requirement := map[string][]string{}
+	if route.Auth {
+		requirement["bearerAuth"] = []string{}
+	}
+	if route.APIKey {
+		requirement["apiKeyAuth"] = []string{}
+	}
+	for name := range requirement {
+		doc.Components.SecuritySchemes[name] = secureSchemes[name]
+	}
+
+	op.Security = []map[string][]string{requirement}
+	op.Responses["401"] = errorResponse("Não autenticado")
+	if len(route.Roles) > 0 {
+		op.Responses["403"] = errorResponse("Permissão negada (papéis: " + strings.Join(route.Roles, ", ") + ")")
+	} else if len(route.Scopes) > 0 {
+		op.Responses["403"] = errorResponse("API key sem os escopos: " + strings.Join(route.Scopes, ", "))
+	}
+}
+
+func errorResponse(description string) *Response {
+	return &Response{Description: description, Content: jsonContent(ref("Error"))}
+}
+
+// pathParameters extrai :param e *param do path
+func pathParameters(path string, model *gaverParser.ModelMetadata) []Parameter {
+	var params []Parameter
+	for _, match := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+		schema := &Schema{Type: "string"}
+		if match[1] == "id" && model != nil {
+			if pk := primaryKey(model); pk != nil {
+				schema = typeSchema(strings.TrimPrefix(pk.Type, "*"), "", nil)
+			}
+		}
+		params = append(params, Parameter{Name: match[1], In: "path", Required: true, Schema: schema})
+	}
+	return params
+}
+
+// listParameters retorna paginação e filtros aceitos pela listagem
+func listParameters(model *gaverParser.ModelMetadata) []Parameter {
+	one := 1.0
+	zero, hundred := 0.0, 100.0
+
+	params := []Parameter{
+		{Name: "page", In: "query", Description: "Página (começa em 1)", Schema: &Schema{Type: "integer", Minimum: &one}},
+		{Name: "limit", In: "query", Description: "Registros por página (0 = todos)", Schema: &Schema{Type: "integer", Minimum: &zero, Maximum: &hundred}},
+	}
+
+	for _, field := range model.Fields {
+		if !field.IsFilterable() {
+			continue
+		}
+		schema := typeSchema(strings.TrimPrefix(field.Type, "*"), "", nil)
+		if enum := field.EnumValues(); enum != nil {
+			schema.Enum = enum
+		}
+		params = append(params, Parameter{
+			Name:        field.JSONName(),
+			In:          "query",
+			Description: fmt.Sprintf("Filtra por %s (igualdade)", field.JSONName()),
+			Schema:      schema,
+		})
+	}
+
+	return params
+}
+
+// readSchema monta o schema de resposta do model (campos readable)
+func readSchema(model *gaverParser.ModelMetadata, module string, names map[string]string) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for _, field := range model.Fields {
+		if !field.IsReadable() || field.JSONName() == "" {
+			continue
+		}
+		schema.Properties[field.JSONName()] = fieldSchema(field, module, names)
+	}
+
+	return schema
+}
+
+// writeSchema monta o schema de entrada do model para o método (POST, PUT, PATCH)
+func writeSchema(model *gaverParser.ModelMetadata, method string) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for _, field := range model.Fields {
+		if field.PrimaryKey || field.JSONName() == "" || !field.IsScalar() || !field.IsWritableInMethod(method) {
+			continue
+		}
+		schema.Properties[field.JSONName()] = fieldSchema(field, "", nil)
+
+		// PATCH é parcial: nenhum campo obrigatório
+		if field.Required && method != "PATCH" {
+			schema.Required = append(schema.Required, field.JSONName())
+		}
+	}
+
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// fieldSchema converte um campo do model em schema, aplicando as validações
+func fieldSchema(field gaverParser.FieldMetadata, module string, names map[string]string) *Schema {
+	goType := field.Type
+	nullable := strings.HasPrefix(goType, "*")
+	goType = strings.TrimPrefix(goType, "*")
+
+	var schema *Schema
+	if strings.HasPrefix(goType, "[]") && goType != "[]byte" {
+		schema = &Schema{Type: "array", Items: typeSchema(strings.TrimPrefix(goType[2:], "*"), module, names)}
+	} else {
+		schema = typeSchema(goType, module, names)
+	}
+
+	applyValidations(schema, field)
+
+	if nullable {
+		if typeName, ok := schema.Type.(string); ok {
+			schema.Type = []string{typeName, "null"}
+		}
+	}
+
+	return schema
+}
+
+// typeSchema converte um tipo Go em schema (models conhecidos viram $ref)
+func typeSchema(goType, module string, names map[string]string) *Schema {
+	switch goType {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32":
+		return &Schema{Type: "integer", Format: "int32"}
+	case "int64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case "float32":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &Schema{Type: "number", Format: "double"}
+	case "time.Time":
+		return &Schema{Type: "string", Format: "date-time"}
+	case "uuid.UUID":
+		return &Schema{Type: "string", Format: "uuid"}
+	case "[]byte":
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	// Relacionamento com outro model do mesmo módulo
+	if name, ok := names[module+"."+goType]; ok {
+		return ref(name)
+	}
+
+	return &Schema{Type: "object"}
+}
+
+// applyValidations traduz as annotations de validação para JSON Schema
+func applyValidations(schema *Schema, field gaverParser.FieldMetadata) {
+	if schema.Ref != "" {
+		return
+	}
+
+	if value, ok := field.Validations["minLength"]; ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			schema.MinLength = &n
+		}
+	}
+	if value, ok := field.Validations["maxLength"]; ok {
+		if n, err := strconv.Atoi(value); err == nil {
+			schema.MaxLength = &n
+		}
+	}
+	if value, ok := field.Validations["min"]; ok {
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			schema.Minimum = &n
+		}
+	}
+	if value, ok := field.Validations["max"]; ok {
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			schema.Maximum = &n
+		}
+	}
+	if value, ok := field.Validations["pattern"]; ok {
+		schema.Pattern = value
+	}
+	if enum := field.EnumValues(); enum != nil {
+		schema.Enum = enum
+	}
+	if _, ok := field.Validations["email"]; ok {
+		schema.Format = "email"
+	}
+	if _, ok := field.Validations["url"]; ok {
+		schema.Format = "uri"
+	}
+}
+
+func primaryKey(model *gaverParser.ModelMetadata) *gaverParser.FieldMetadata {
+	for i := range model.Fields {
+		if model.Fields[i].PrimaryKey || model.Fields[i].Name == "ID" {
+			return &model.Fields[i]
+		}
+	}
+	return nil
+}
+
+// toOperationName converte um path em sufixo de operationId (/health/db -> HealthDb)
+func toOperationName(path string) string {
+	var result strings.Builder
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == ':' || r == '*' || r == '_' || r == '-'
+	}) {
+		result.WriteString(generator.Capitalize(part))
+	}
+	return result.String()
+}
@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+	gaverParser "github.com/Dalistor/gaver/pkg/parser"
+)
+
+func testModule() *Module {
+	return &Module{
+		Name: "shop",
+		Models: map[string]*gaverParser.ModelMetadata{
+			"Product": {
+				Name: "Product",
+				Fields: []gaverParser.FieldMetadata{
+					{Name: "ID", Type: "uint", PrimaryKey: true, Readable: true, JSONTag: "id"},
+					{Name: "Name", Type: "string", Readable: true, Required: true, JSONTag: "name"},
+				},
+			},
+		},
+		Routes: []Route{
+			{Module: "shop", Method: "GET", Path: "/products", Model: "Product", Action: "List"},
+			{Module: "shop", Method: "GET", Path: "/products/:id", Model: "Product", Action: "Get"},
+			{Module: "shop", Method: "POST", Path: "/products", Model: "Product", Action: "Create"},
+			{Module: "shop", Method: "PUT", Path: "/products/:id", Model: "Product", Action: "Update"},
+			{Module: "shop", Method: "PATCH", Path: "/products/:id", Model: "Product", Action: "Patch"},
+			{Module: "shop", Method: "DELETE", Path: "/products/:id", Model: "Product", Action: "Delete"},
+		},
+	}
+}
+
+func responseCodes(op *Operation) []string {
+	var codes []string
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func TestCRUDResponseCodes(t *testing.T) {
+	doc := NewBuilder("Teste", "1.0.0").Build([]*Module{testModule()})
+
+	tests := []struct {
+		method string
+		path   string
+		codes  []string
+	}{
+		{"get", "/products", []string{"200", "400", "500"}},
+		{"get", "/products/{id}", []string{"200", "400", "404"}},
+		{"post", "/products", []string{"201", "400", "500"}},
+		{"put", "/products/{id}", []string{"200", "400", "404", "500"}},
+		{"patch", "/products/{id}", []string{"200", "400", "404", "500"}},
+		{"delete", "/products/{id}", []string{"204", "400", "404", "500"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			op := doc.Paths[tt.path][tt.method]
+			if op == nil {
+				t.Fatalf("operação %s %s não gerada", tt.method, tt.path)
+			}
+			if codes := responseCodes(op); !slices.Equal(codes, tt.codes) {
+				t.Errorf("status = %v, esperado %v", codes, tt.codes)
+			}
+		})
+	}
+}
+
+func TestAuthResponseCodes(t *testing.T) {
+	module := &Module{
+		Name:   "auth",
+		Models: map[string]*gaverParser.ModelMetadata{},
+		Routes: []Route{
+			{Module: "auth", Method: "POST", Path: "/auth/register", Model: "Auth", Action: "Register"},
+			{Module: "auth", Method: "POST", Path: "/auth/logout", Model: "Auth", Action: "Logout"},
+			{Module: "auth", Method: "GET", Path: "/auth/me", Model: "Auth", Action: "Me", Auth: true},
+		},
+	}
+	doc := NewBuilder("Teste", "1.0.0").Build([]*Module{module})
+
+	tests := []struct {
+		method string
+		path   string
+		codes  []string
+	}{
+		{"post", "/auth/register", []string{"201", "400", "403", "409", "500"}},
+		{"post", "/auth/logout", []string{"204", "400", "500"}},
+		{"get", "/auth/me", []string{"200", "401", "404"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			op := doc.Paths[tt.path][tt.method]
+			if op == nil {
+				t.Fatalf("operação %s %s não gerada", tt.method, tt.path)
+			}
+			if codes := responseCodes(op); !slices.Equal(codes, tt.codes) {
+				t.Errorf("status = %v, esperado %v", codes, tt.codes)
+			}
+		})
+	}
+}
+
+// statusNames traduz as constantes usadas nos templates dos handlers
+var statusNames = map[string]int{
+	"StatusOK":                  http.StatusOK,
+	"StatusCreated":             http.StatusCreated,
+	"StatusNoContent":           http.StatusNoContent,
+	"StatusBadRequest":          http.StatusBadRequest,
+	"StatusUnauthorized":        http.StatusUnauthorized,
+	"StatusForbidden":           http.StatusForbidden,
+	"StatusNotFound":            http.StatusNotFound,
+	"StatusConflict":            http.StatusConflict,
+	"StatusInternalServerError": http.StatusInternalServerError,
+}
+
+var (
+	handlerFuncRegex = regexp.MustCompile(`(?m)^func \(h \*[^)]+\) (\w+)\(c \*gin\.Context\) \{$`)
+	statusRegex      = regexp.MustCompile(`http\.(Status\w+)`)
+)
+
+// templateStatuses lê os status respondidos por cada método do handler
+func templateStatuses(t *testing.T, name string) map[string][]int {
+	t.Helper()
+	src, err := templates.TemplatesFS.ReadFile(name)
+	if err != nil {
+		t.Fatalf("erro ao ler %s: %v", name, err)
+	}
+
+	statuses := map[string][]int{}
+	text := string(src)
+	for _, match := range handlerFuncRegex.FindAllStringSubmatchIndex(text, -1) {
+		action := text[match[2]:match[3]]
+		body := text[match[1]:]
+		body = body[:strings.Index(body, "\n}")]
+
+		for _, status := range statusRegex.FindAllStringSubmatch(body, -1) {
+			code, ok := statusNames[status[1]]
+			if !ok {
+				t.Fatalf("%s: %s sem código em statusNames", name, status[1])
+			}
+			if !slices.Contains(statuses[action], code) {
+				statuses[action] = append(statuses[action], code)
+			}
+		}
+	}
+	return statuses
+}
+
+func tableCodes(responses []statusResponse) []string {
+	var codes []string
+	for _, r := range responses {
+		codes = append(codes, strconv.Itoa(r.Status))
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func intCodes(statuses []int) []string {
+	var codes []string
+	for _, status := range statuses {
+		codes = append(codes, strconv.Itoa(status))
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// TestResponsesMatchHandlers garante que as tabelas de status documentam
+// exatamente os status dos handlers gerados
+func TestResponsesMatchHandlers(t *testing.T) {
+	tables := []struct {
+		template  string
+		responses map[string][]statusResponse
+	}{
+		{"module_handler.tmpl", crudResponses},
+		{"auth_handler.tmpl", authResponses},
+	}
+
+	for _, table := range tables {
+		handlers := templateStatuses(t, table.template)
+		for action, responses := range table.responses {
+			statuses, ok := handlers[action]
+			if !ok {
+				t.Errorf("%s: método %s não encontrado", table.template, action)
+				continue
+			}
+			if got, want := tableCodes(responses), intCodes(statuses); !slices.Equal(got, want) {
+				t.Errorf("%s %s: tabela = %v, handler responde %v", table.template, action, got, want)
+			}
+		}
+	}
+}
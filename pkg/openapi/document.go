@@ -0,0 +1,119 @@
+package openapi
+
+// Version é a versão da especificação OpenAPI gerada
+const Version = "3.1.0"
+
+// Document representa um documento OpenAPI 3.1
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers,omitempty"`
+	Tags       []Tag                            `json:"tags,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+// Info contém os metadados da API
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Server representa uma URL base da API
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Tag agrupa operações (uma por módulo)
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Components contém os schemas e esquemas de segurança reutilizáveis
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme descreve uma forma de autenticação
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+// Operation representa uma rota (método + path)
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter representa um parâmetro de path ou query
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody representa o corpo de uma requisição
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response representa uma resposta de uma operação
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header representa um header de resposta
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType associa um schema a um content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema representa um JSON Schema (subconjunto usado pelo gerador)
+//
+// Type é string ou []string (OpenAPI 3.1 usa ["string", "null"] para nullable).
+type Schema struct {
+	Ref        string             `json:"$ref,omitempty"`
+	Type       interface{}        `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+	MinLength  *int               `json:"minLength,omitempty"`
+	MaxLength  *int               `json:"maxLength,omitempty"`
+	Minimum    *float64           `json:"minimum,omitempty"`
+	Maximum    *float64           `json:"maximum,omitempty"`
+	Pattern    string             `json:"pattern,omitempty"`
+}
+
+// jsonContent monta o content application/json de um schema
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{
+		"application/json": {Schema: schema},
+	}
+}
+
+// ref cria uma referência para um schema de components
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
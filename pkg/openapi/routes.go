@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	gaverParser "github.com/Dalistor/gaver/pkg/parser"
+)
+
+// Route representa uma rota registrada em RegisterRoutes
+type Route struct {
+	Module string
+	Method string   // GET, POST, PUT, PATCH, DELETE
+	Path   string   // formato gin: /products/:id
+	Model  string   // model do handler (vazio se não for um handler gerado)
+	Action string   // List, Get, Create, Update, Patch, Delete
+	Auth   bool     // protegida por middlewares.Auth()
+	Roles  []string // papéis exigidos por middlewares.RequireRole(...)
+	APIKey bool     // protegida por middlewares.APIKeyAuth(...)
+	Scopes []string // escopos exigidos da API key
+}
+
+// Module contém as rotas e os models de um módulo
+type Module struct {
+	Name   string
+	Routes []Route
+	Models map[string]*gaverParser.ModelMetadata
+}
+
+// ScanModules lê todos os módulos do diretório informado (normalmente "modules")
+func ScanModules(modulesPath string) ([]*Module, error) {
+	entries, err := os.ReadDir(modulesPath)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", modulesPath, err)
+	}
+
+	var modules []*Module
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		module, err := scanModule(filepath.Join(modulesPath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Name < modules[j].Name
+	})
+
+	return modules, nil
+}
+
+func scanModule(modulePath string) (*Module, error) {
+	module := &Module{
+		Name:   filepath.Base(modulePath),
+		Models: map[string]*gaverParser.ModelMetadata{},
+	}
+
+	// Models
+	modelFiles, _ := filepath.Glob(filepath.Join(modulePath, "models", "*.go"))
+	for _, file := range modelFiles {
+		metadata, err := gaverParser.ParseModelFile(file)
+		if err != nil {
+			// Ignorar arquivos que não são models
+			continue
+		}
+		module.Models[metadata.Name] = metadata
+	}
+
+	// Rotas
+	moduleFile := filepath.Join(modulePath, "module.go")
+	if _, err := os.Stat(moduleFile); os.IsNotExist(err) {
+		return module, nil
+	}
+
+	routes, err := parseRoutes(moduleFile)
+	if err != nil {
+		return nil, err
+	}
+	for i := range routes {
+		routes[i].Module = module.Name
+	}
+	module.Routes = routes
+
+	return module, nil
+}
+
+// parseRoutes extrai as chamadas router.METHOD("path", handler.Action) de RegisterRoutes
+func parseRoutes(moduleFile string) ([]Route, error) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, moduleFile, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao parsear %s: %w", moduleFile, err)
+	}
+
+	var fn *ast.FuncDecl
+	for _, decl := range node.Decls {
+		if f, ok := decl.(*ast.FuncDecl); ok && f.Name.Name == "RegisterRoutes" && f.Body != nil {
+			fn = f
+			break
+		}
+	}
+	if fn == nil {
+		return nil, nil
+	}
+
+	// Variável do handler -> model (productHandler := handlers.NewProductHandler(...))
+	handlerModels := map[string]string{}
+	var routes []Route
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+				return true
+			}
+			ident, ok := stmt.Lhs[0].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if name := calledFunc(stmt.Rhs[0]); strings.HasPrefix(name, "New") && strings.HasSuffix(name, "Handler") {
+				handlerModels[ident.Name] = strings.TrimSuffix(strings.TrimPrefix(name, "New"), "Handler")
+			}
+
+		case *ast.CallExpr:
+			route, ok := routeFromCall(stmt, handlerModels)
+			if ok {
+				routes = append(routes, route)
+			}
+		}
+		return true
+	})
+
+	return routes, nil
+}
+
+// calledFunc retorna o nome da função chamada em pkg.Func(...) ou Func(...)
+func calledFunc(expr ast.Expr) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	case *ast.Ident:
+		return fun.Name
+	}
+	return ""
+}
+
+func routeFromCall(call *ast.CallExpr, handlerModels map[string]string) (Route, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) < 2 {
+		return Route{}, false
+	}
+
+	switch sel.Sel.Name {
+	case "GET", "POST", "PUT", "PATCH", "DELETE":
+	default:
+		return Route{}, false
+	}
+
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return Route{}, false
+	}
+	path, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return Route{}, false
+	}
+
+	route := Route{Method: sel.Sel.Name, Path: path}
+
+	// Middlewares de autenticação entre o path e o handler
+	for _, arg := range call.Args[1 : len(call.Args)-1] {
+		mw, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		switch calledFunc(mw) {
+		case "Auth":
+			route.Auth = true
+		case "RequireRole":
+			route.Roles = append(route.Roles, stringArgs(mw)...)
+		case "APIKeyAuth":
+			route.APIKey = true
+			route.Scopes = append(route.Scopes, stringArgs(mw)...)
+		}
+	}
+
+	// O handler é o último argumento (middlewares vêm antes)
+	if handler, ok := call.Args[len(call.Args)-1].(*ast.SelectorExpr); ok {
+		if ident, ok := handler.X.(*ast.Ident); ok {
+			route.Model = handlerModels[ident.Name]
+			route.Action = handler.Sel.Name
+		}
+	}
+
+	return route, true
+}
+
+// stringArgs retorna os argumentos literais string de uma chamada
+func stringArgs(call *ast.CallExpr) []string {
+	var values []string
+	for _, arg := range call.Args {
+		if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if value, err := strconv.Unquote(lit.Value); err == nil {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	templates "github.com/Dalistor/gaver/internal/templates"
+)
+
+// DocsPackageFile é o pacote que serve o documento em /api/docs
+var DocsPackageFile = filepath.Join("config", "docs", "docs.go")
+
+// DefaultOutput é o arquivo embarcado pelo pacote config/docs
+var DefaultOutput = filepath.Join("config", "docs", "openapi.json")
+
+// WriteDocument grava o documento em JSON indentado
+func WriteDocument(doc *Document, outputPath string) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar documento: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório: %w", err)
+	}
+
+	return os.WriteFile(outputPath, append(data, '\n'), 0644)
+}
+
+// EnsureDocsPackage cria config/docs/docs.go em projetos gerados antes do
+// endpoint /api/docs existir. Retorna true se o arquivo foi criado.
+func EnsureDocsPackage(projectName string) (bool, error) {
+	if _, err := os.Stat(DocsPackageFile); err == nil {
+		return false, nil
+	}
+
+	gen := templates.New(".")
+	data := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+
+	if err := gen.Generate("config_docs.tmpl", DocsPackageFile, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -7,7 +7,9 @@ import (
 	"go/token"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
 )
 
 // FieldMetadata contém metadados de um campo do model
@@ -29,6 +31,7 @@ type FieldMetadata struct {
 	Ignore      bool
 	IgnoreWrite bool
 	IgnoreRead  bool
+	Owner       bool // campo com o ID do usuário dono do registro
 }
 
 // Relation representa um relacionamento entre models
@@ -41,13 +44,25 @@ type Relation struct {
 
 // ModelMetadata contém metadados completos de um model
 type ModelMetadata struct {
-	Name       string
-	Package    string
-	TableName  string
-	Fields     []FieldMetadata
-	Imports    []string
+	Name        string
+	Package     string
+	TableName   string
+	Fields      []FieldMetadata
+	Imports     []string
+	Permissions map[string][]string // ação (list, get, create, update, patch, delete, *) -> papéis
+	Unprefixed  []string            // comentários do struct com permissions/owner sem "gaverModel:"
 }
 
+// Papéis especiais usados em permissions
+const (
+	PermissionAny   = "any"   // rota pública
+	PermissionAuth  = "auth"  // qualquer usuário autenticado
+	PermissionOwner = "owner" // usuário autenticado, restrito aos próprios registros
+)
+
+// PermissionActions são as ações aceitas em permissions ("*" vale para as não listadas)
+var PermissionActions = []string{"list", "get", "create", "update", "patch", "delete"}
+
 // ParseModelFile lê um arquivo .go e extrai metadata
 func ParseModelFile(filePath string) (*ModelMetadata, error) {
 	fset := token.NewFileSet()
@@ -69,31 +84,51 @@ func ParseModelFile(filePath string) (*ModelMetadata, error) {
 		}
 	}
 
-	// Encontrar struct type
-	ast.Inspect(node, func(n ast.Node) bool {
-		typeSpec, ok := n.(*ast.TypeSpec)
-		if !ok {
-			return true
+	// Encontrar struct type (a annotation do model fica no comentário do tipo)
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
 		}
 
-		structType, ok := typeSpec.Type.(*ast.StructType)
-		if !ok {
-			return true
-		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
 
-		metadata.Name = typeSpec.Name.Name
-		metadata.TableName = toSnakeCase(pluralize(typeSpec.Name.Name))
+			metadata.Name = typeSpec.Name.Name
+			// Mesma convenção do GORM: OrderItem -> order_items, APIKey -> api_keys
+			metadata.TableName = Pluralize(columnName(typeSpec.Name.Name))
 
-		// Parsear cada campo
-		for _, field := range structType.Fields.List {
-			if len(field.Names) > 0 {
-				fieldMeta := parseField(field)
-				metadata.Fields = append(metadata.Fields, fieldMeta)
+			doc := typeSpec.Doc
+			if doc == nil {
+				doc = genDecl.Doc
+			}
+			if doc != nil {
+				for _, comment := range doc.List {
+					if isAnnotation(comment.Text) {
+						parseModelAnnotation(comment.Text, metadata)
+					} else if isUnprefixedAnnotation(comment.Text) {
+						metadata.Unprefixed = append(metadata.Unprefixed, comment.Text)
+					}
+				}
 			}
-		}
 
-		return false
-	})
+			// Parsear cada campo
+			for _, field := range structType.Fields.List {
+				if len(field.Names) > 0 {
+					fieldMeta := parseField(field)
+					metadata.Fields = append(metadata.Fields, fieldMeta)
+				}
+			}
+			break
+		}
+		if metadata.Name != "" {
+			break
+		}
+	}
 
 	if metadata.Name == "" {
 		return nil, fmt.Errorf("nenhuma struct encontrada no arquivo")
@@ -122,9 +157,8 @@ func parseField(field *ast.Field) FieldMetadata {
 	// Parsear annotation gaverModel dos comentários
 	if field.Doc != nil {
 		for _, comment := range field.Doc.List {
-			text := comment.Text
-			if strings.HasPrefix(text, "// gaverModel:") || strings.HasPrefix(text, "//gaverModel:") {
-				parseAnnotation(text, &meta)
+			if isAnnotation(comment.Text) {
+				parseAnnotation(comment.Text, &meta)
 			}
 		}
 	}
@@ -132,11 +166,60 @@ func parseField(field *ast.Field) FieldMetadata {
 	return meta
 }
 
-func parseAnnotation(comment string, meta *FieldMetadata) {
-	// Remove "// gaverModel:" ou "//gaverModel:"
+func isAnnotation(comment string) bool {
+	return strings.HasPrefix(comment, "// gaverModel:") || strings.HasPrefix(comment, "//gaverModel:")
+}
+
+// isUnprefixedAnnotation indica um comentário de struct escrito como
+// annotation, mas sem o prefixo (ex: "// permissions:list=any"): o parser o
+// ignoraria e as rotas ficariam públicas
+func isUnprefixedAnnotation(comment string) bool {
+	content := strings.TrimPrefix(strings.TrimPrefix(comment, "//"), " ")
+	return strings.HasPrefix(content, "permissions:") || strings.HasPrefix(content, "owner:")
+}
+
+// annotationContent remove "// gaverModel:" ou "//gaverModel:"
+func annotationContent(comment string) string {
 	content := strings.TrimPrefix(comment, "// gaverModel:")
 	content = strings.TrimPrefix(content, "//gaverModel:")
-	content = strings.TrimSpace(content)
+	return strings.TrimSpace(content)
+}
+
+// parseModelAnnotation lê a annotation do struct:
+//
+//	// gaverModel: permissions:list=any,create=auth,update=admin|owner
+//
+// Várias tags no mesmo comentário são separadas por ";".
+func parseModelAnnotation(comment string, meta *ModelMetadata) {
+	for _, part := range strings.Split(annotationContent(comment), ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), ":")
+		if !found {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "permissions":
+			if meta.Permissions == nil {
+				meta.Permissions = map[string][]string{}
+			}
+			for _, rule := range strings.Split(value, ",") {
+				action, roles, found := strings.Cut(rule, "=")
+				if !found {
+					continue
+				}
+				action = strings.ToLower(strings.TrimSpace(action))
+				for _, role := range strings.Split(roles, "|") {
+					if role = strings.TrimSpace(role); role != "" {
+						meta.Permissions[action] = append(meta.Permissions[action], role)
+					}
+				}
+			}
+		}
+	}
+}
+
+func parseAnnotation(comment string, meta *FieldMetadata) {
+	content := annotationContent(comment)
 
 	// Split por ";" ou ","
 	parts := splitAnnotation(content)
@@ -217,6 +300,8 @@ func parseAnnotation(comment string, meta *FieldMetadata) {
 				meta.Index = true
 			case "ignore":
 				meta.Ignore = true
+			case "owner":
+				meta.Owner = true
 			case "email":
 				meta.Validations["email"] = "true"
 			case "url":
@@ -281,15 +366,18 @@ func ToSnakeCase(s string) string {
 	return toSnakeCase(s)
 }
 
-func pluralize(s string) string {
-	s = strings.ToLower(s)
-	if strings.HasSuffix(s, "s") {
+// Pluralize pluraliza o nome mantendo a capitalização (OrderItem ->
+// OrderItems, order_item -> order_items)
+func Pluralize(s string) string {
+	lower := strings.ToLower(s)
+	if strings.HasSuffix(lower, "s") {
 		return s
 	}
-	if strings.HasSuffix(s, "y") {
+	// key -> keys, category -> categories
+	if strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])) {
 		return s[:len(s)-1] + "ies"
 	}
-	if strings.HasSuffix(s, "ch") || strings.HasSuffix(s, "sh") || strings.HasSuffix(s, "x") {
+	if strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh") || strings.HasSuffix(lower, "x") {
 		return s + "es"
 	}
 	return s + "s"
@@ -396,8 +484,9 @@ func getSQLiteType(goType string) string {
 func (f *FieldMetadata) IsWritableInMethod(method string) bool {
 	method = strings.ToUpper(method)
 
-	// Se ignore ou ignoreWrite, não pode escrever
-	if f.Ignore || f.IgnoreWrite {
+	// Se ignore ou ignoreWrite, não pode escrever. O dono vem do usuário
+	// autenticado, nunca do corpo da requisição
+	if f.Ignore || f.IgnoreWrite || f.Owner {
 		return false
 	}
 
@@ -511,3 +600,123 @@ func validateMaxLength(str, maxLen string) bool {
 	return true
 }
 
+
+// JSONName retorna o nome do campo no JSON ("" se o campo não é serializado)
+func (f *FieldMetadata) JSONName() string {
+	name := strings.Split(f.JSONTag, ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// ColumnName retorna o nome da coluna no banco (tag gorm column: ou convenção do GORM)
+func (f *FieldMetadata) ColumnName() string {
+	for _, part := range strings.Split(f.GORMTag, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "column:") {
+			return strings.TrimPrefix(part, "column:")
+		}
+	}
+	return columnName(f.Name)
+}
+
+// IsScalar verifica se o tipo do campo é um valor simples (não struct nem slice)
+func (f *FieldMetadata) IsScalar() bool {
+	switch strings.TrimPrefix(f.Type, "*") {
+	case "string", "bool",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64",
+		"time.Time", "uuid.UUID":
+		return true
+	}
+	return false
+}
+
+// IsFilterable verifica se o campo pode ser usado como filtro na listagem
+// (datas ficam de fora: igualdade exata em timestamp não é um filtro útil)
+func (f *FieldMetadata) IsFilterable() bool {
+	return f.IsReadable() && f.JSONName() != "" && f.IsScalar() && strings.TrimPrefix(f.Type, "*") != "time.Time"
+}
+
+// EnumValues retorna os valores permitidos pela annotation enum
+func (f *FieldMetadata) EnumValues() []string {
+	enum, ok := f.Validations["enum"]
+	if !ok || enum == "" {
+		return nil
+	}
+
+	values := strings.Split(enum, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+	return values
+}
+
+// PermissionFor retorna os papéis exigidos pela ação (nil = rota pública).
+// patch sem permissão própria herda a de update: as duas rotas alteram o
+// mesmo registro.
+func (m *ModelMetadata) PermissionFor(action string) []string {
+	if roles, ok := m.Permissions[action]; ok {
+		return roles
+	}
+	if action == "patch" {
+		if roles, ok := m.Permissions["update"]; ok {
+			return roles
+		}
+	}
+	return m.Permissions["*"]
+}
+
+// OwnerField retorna o campo com a annotation owner (nil se o model não tiver dono)
+func (m *ModelMetadata) OwnerField() *FieldMetadata {
+	for i := range m.Fields {
+		if m.Fields[i].Owner {
+			return &m.Fields[i]
+		}
+	}
+	return nil
+}
+
+// ValidatePermissions verifica as ações de permissions e se há campo owner
+// quando alguma ação é restrita ao dono
+func (m *ModelMetadata) ValidatePermissions() error {
+	for _, comment := range m.Unprefixed {
+		content := strings.TrimSpace(strings.TrimPrefix(comment, "//"))
+		return fmt.Errorf("comentário '%s' de %s seria ignorado: use '// gaverModel: %s'", comment, m.Name, content)
+	}
+
+	for action, roles := range m.Permissions {
+		if action != "*" && !slices.Contains(PermissionActions, action) {
+			return fmt.Errorf("ação '%s' inválida em permissions (use %s ou *)", action, strings.Join(PermissionActions, ", "))
+		}
+		if slices.Contains(roles, PermissionOwner) && m.OwnerField() == nil {
+			return fmt.Errorf("permissão '%s=owner' exige um campo com a annotation owner em %s", action, m.Name)
+		}
+	}
+	return nil
+}
+
+// columnName converte o nome do campo em nome de coluna como o GORM faz
+// (UserID -> user_id, HTTPStatus -> http_status)
+func columnName(name string) string {
+	runes := []rune(name)
+	var result strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				result.WriteRune('_')
+			}
+		}
+		result.WriteRune(unicode.ToLower(r))
+	}
+
+	return result.String()
+}
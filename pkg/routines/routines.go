@@ -0,0 +1,142 @@
+package routines
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Dalistor/gaver/pkg/editor"
+)
+
+// routinesFile é o arquivo onde ficam as rotinas do projeto
+var routinesFile = filepath.Join("config", "routines", "routines.go")
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Options configura a rotina gerada
+type Options struct {
+	Every       time.Duration // intervalo (ignorado quando Cron é informado)
+	Cron        string        // expressão cron
+	Timeout     time.Duration // 0 = sem timeout
+	WithoutLock bool
+	InitialRun  bool
+}
+
+// Create adiciona o esqueleto de uma rotina em RegisterDefaultRoutines
+func Create(name string, opts Options) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("nome inválido: %s (use letras minúsculas, números e _, ex: send_reports)", name)
+	}
+
+	schedule, err := scheduleExpr(opts)
+	if err != nil {
+		return err
+	}
+
+	file, err := editor.Open(routinesFile)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir %s (execute dentro de um projeto Gaver): %w", routinesFile, err)
+	}
+
+	fn := file.FindFunc("Manager", "RegisterDefaultRoutines")
+	if fn == nil {
+		return fmt.Errorf("função RegisterDefaultRoutines não encontrada em %s", routinesFile)
+	}
+	recv := "m"
+	if len(fn.Recv.List) > 0 && len(fn.Recv.List[0].Names) > 0 {
+		recv = fn.Recv.List[0].Names[0].Name
+	}
+
+	exists, err := file.HasStmt("Manager", "RegisterDefaultRoutines", func(stmt ast.Stmt) bool {
+		return editor.CallsMethod(stmt, recv, "Register", name) ||
+			editor.CallsMethod(stmt, recv, "RegisterTask", name) ||
+			editor.CallsMethod(stmt, recv, "RegisterCron", name)
+	})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("a rotina %s já está registrada em %s", name, routinesFile)
+	}
+
+	var options []string
+	if opts.Timeout > 0 {
+		options = append(options, fmt.Sprintf("WithTimeout(%s)", durationExpr(opts.Timeout)))
+	}
+	if opts.InitialRun {
+		options = append(options, "WithInitialRun()")
+	}
+	if opts.WithoutLock {
+		options = append(options, "WithoutLock()")
+	}
+
+	closing := "})"
+	if len(options) > 0 {
+		closing = "}, " + strings.Join(options, ", ") + ")"
+	}
+
+	code := fmt.Sprintf(`// Rotina %s
+%s.RegisterTask(%q, %s, func(ctx context.Context) error {
+	// TODO: implementar a rotina %s
+	return nil
+%s`, name, recv, name, schedule, name, closing)
+
+	if err := file.AppendStmts("Manager", "RegisterDefaultRoutines", code); err != nil {
+		return err
+	}
+	if err := file.AddImport("context"); err != nil {
+		return err
+	}
+	if opts.Cron == "" || opts.Timeout > 0 {
+		if err := file.AddImport("time"); err != nil {
+			return err
+		}
+	}
+
+	return file.Save()
+}
+
+// scheduleExpr retorna a expressão Go do agendamento
+func scheduleExpr(opts Options) (string, error) {
+	if opts.Cron != "" {
+		expr := strings.TrimSpace(opts.Cron)
+		spec := expr
+		if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+			if i := strings.IndexByte(spec, ' '); i >= 0 {
+				spec = strings.TrimSpace(spec[i+1:])
+			}
+		}
+		if !strings.HasPrefix(spec, "@") && len(strings.Fields(spec)) != 5 {
+			return "", fmt.Errorf("expressão cron inválida: %q (esperados 5 campos: minuto hora dia mês dia-da-semana)", opts.Cron)
+		}
+		return fmt.Sprintf("MustCron(%q)", expr), nil
+	}
+
+	if opts.Every <= 0 {
+		return "", fmt.Errorf("intervalo inválido: %s", opts.Every)
+	}
+	return fmt.Sprintf("Every(%s)", durationExpr(opts.Every)), nil
+}
+
+// durationExpr converte a duração em uma expressão Go legível (ex: 90*time.Minute)
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d*%s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}